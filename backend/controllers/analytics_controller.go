@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"your-project/backend/middleware"
+	"your-project/backend/models"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AnalyticsController представляет контроллер статистики просмотров
+type AnalyticsController struct {
+	analyticsService *services.AnalyticsService
+	projectService   *services.ProjectService
+}
+
+// NewAnalyticsController создает новый контроллер статистики просмотров
+func NewAnalyticsController(analyticsService *services.AnalyticsService, projectService *services.ProjectService) *AnalyticsController {
+	return &AnalyticsController{
+		analyticsService: analyticsService,
+		projectService:   projectService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты статистики просмотров
+func (c *AnalyticsController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/:id/analytics", middleware.AuthMiddleware(), c.GetUserAnalytics)
+	router.GET("/projects/:id/analytics", middleware.AuthMiddleware(), c.GetProjectAnalytics)
+}
+
+// GetUserAnalytics возвращает статистику просмотров профиля его владельцу
+func (c *AnalyticsController) GetUserAnalytics(ctx *gin.Context) {
+	id := ctx.Param("id")
+	userID, exists := ctx.Get("user_id")
+	if !exists || userID != id {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only view analytics of your own profile"})
+		return
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	c.respondAnalytics(ctx, models.ViewTargetUser, objectID)
+}
+
+// GetProjectAnalytics возвращает статистику просмотров проекта его владельцу
+func (c *AnalyticsController) GetProjectAnalytics(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	// Получить проект для проверки владельца
+	project, err := c.projectService.GetProjectByID(ctx, id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	// Проверить, что пользователь является владельцем проекта
+	if project.UserID.Hex() != ctx.GetString("user_id") {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only view analytics of your own projects"})
+		return
+	}
+
+	c.respondAnalytics(ctx, models.ViewTargetProject, project.ID)
+}
+
+// respondAnalytics отправляет статистику за период, заданный параметром days
+func (c *AnalyticsController) respondAnalytics(ctx *gin.Context, targetType string, targetID primitive.ObjectID) {
+	days := services.DefaultAnalyticsDays
+	if value := ctx.Query("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > services.MaxAnalyticsDays {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'days' must be between 1 and " + strconv.Itoa(services.MaxAnalyticsDays)})
+			return
+		}
+		days = parsed
+	}
+
+	analytics, err := c.analyticsService.GetAnalytics(ctx, targetType, targetID, days)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, analytics)
+}
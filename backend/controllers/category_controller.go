@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"your-project/backend/middleware"
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryController представляет контроллер категорий проектов
+type CategoryController struct {
+	categoryService *services.CategoryService
+	adminIDs        []string
+}
+
+// NewCategoryController создает новый контроллер категорий; изменять категории могут пользователи из adminIDs
+func NewCategoryController(categoryService *services.CategoryService, adminIDs []string) *CategoryController {
+	return &CategoryController{
+		categoryService: categoryService,
+		adminIDs:        adminIDs,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты категорий
+func (c *CategoryController) RegisterRoutes(router *gin.RouterGroup) {
+	categories := router.Group("/categories")
+	{
+		categories.GET("", c.GetCategories)
+
+		admin := categories.Group("", middleware.AuthMiddleware(), middleware.AdminMiddleware(c.adminIDs))
+		admin.POST("", c.CreateCategory)
+		admin.PUT("/:slug", c.UpdateCategory)
+		admin.DELETE("/:slug", c.DeleteCategory)
+	}
+}
+
+// GetCategories возвращает все категории
+func (c *CategoryController) GetCategories(ctx *gin.Context) {
+	categories, err := c.categoryService.GetCategories(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, categories)
+}
+
+// CreateCategory создает категорию
+func (c *CategoryController) CreateCategory(ctx *gin.Context) {
+	var request struct {
+		Slug        string `json:"slug" binding:"required"`
+		Name        string `json:"name" binding:"required"`
+		Description string `json:"description"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := c.categoryService.CreateCategory(ctx, models.Category{
+		Slug:        request.Slug,
+		Name:        request.Name,
+		Description: request.Description,
+	})
+	if err != nil {
+		respondCategoryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory изменяет название и описание категории
+func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
+	var request struct {
+		Name        string `json:"name" binding:"required"`
+		Description string `json:"description"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := c.categoryService.UpdateCategory(ctx, ctx.Param("slug"), request.Name, request.Description)
+	if err != nil {
+		respondCategoryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory удаляет категорию; проекты этой категории остаются без категории
+func (c *CategoryController) DeleteCategory(ctx *gin.Context) {
+	if err := c.categoryService.DeleteCategory(ctx, ctx.Param("slug")); err != nil {
+		respondCategoryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
+}
+
+// respondCategoryError отправляет ответ об ошибке изменения категории
+func respondCategoryError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCategory):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCategoryNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrCategoryExists):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"your-project/backend/middleware"
+	"your-project/backend/models"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CollectionController представляет контроллер подборок проектов
+type CollectionController struct {
+	collectionService *services.CollectionService
+}
+
+// NewCollectionController создает новый контроллер подборок
+func NewCollectionController(collectionService *services.CollectionService) *CollectionController {
+	return &CollectionController{
+		collectionService: collectionService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты подборок
+func (c *CollectionController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/:id/collections", middleware.OptionalAuthMiddleware(), c.GetUserCollections)
+
+	collections := router.Group("/collections")
+	{
+		collections.GET("/:id", middleware.OptionalAuthMiddleware(), c.GetCollection)
+		collections.POST("", middleware.AuthMiddleware(), c.CreateCollection)
+		collections.PUT("/:id", middleware.AuthMiddleware(), c.UpdateCollection)
+		collections.DELETE("/:id", middleware.AuthMiddleware(), c.DeleteCollection)
+		collections.POST("/:id/projects", middleware.AuthMiddleware(), c.AddProject)
+		collections.DELETE("/:id/projects/:projectId", middleware.AuthMiddleware(), c.RemoveProject)
+		collections.PUT("/:id/order", middleware.AuthMiddleware(), c.ReorderProjects)
+	}
+}
+
+// GetUserCollections возвращает подборки пользователя; приватные подборки видит только их автор
+func (c *CollectionController) GetUserCollections(ctx *gin.Context) {
+	collections, err := c.collectionService.GetUserCollections(ctx, ctx.Param("id"), ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collections)
+}
+
+// GetCollection возвращает подборку с проектами
+func (c *CollectionController) GetCollection(ctx *gin.Context) {
+	page, err := c.collectionService.GetCollectionPage(ctx, ctx.Param("id"), ctx.GetString("user_id"))
+	if err != nil {
+		respondCollectionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// CreateCollection создает подборку текущего пользователя
+func (c *CollectionController) CreateCollection(ctx *gin.Context) {
+	var collection models.Collection
+	if err := ctx.ShouldBindJSON(&collection); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := c.collectionService.CreateCollection(ctx, ctx.GetString("user_id"), collection)
+	if err != nil {
+		respondCollectionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, created)
+}
+
+// UpdateCollection изменяет название, описание и видимость подборки; доступно только автору
+func (c *CollectionController) UpdateCollection(ctx *gin.Context) {
+	collection, ok := c.requireCollectionOwner(ctx)
+	if !ok {
+		return
+	}
+
+	var changes models.Collection
+	if err := ctx.ShouldBindJSON(&changes); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := c.collectionService.UpdateCollection(ctx, collection, changes)
+	if err != nil {
+		respondCollectionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updated)
+}
+
+// DeleteCollection удаляет подборку; доступно только автору
+func (c *CollectionController) DeleteCollection(ctx *gin.Context) {
+	collection, ok := c.requireCollectionOwner(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.collectionService.DeleteCollection(ctx, collection); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Collection deleted successfully"})
+}
+
+// AddProject добавляет проект в конец подборки; доступно только автору
+func (c *CollectionController) AddProject(ctx *gin.Context) {
+	collection, ok := c.requireCollectionOwner(ctx)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		ProjectID string `json:"projectId" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := c.collectionService.AddProject(ctx, collection, request.ProjectID)
+	if err != nil {
+		respondCollectionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// RemoveProject удаляет проект из подборки; доступно только автору
+func (c *CollectionController) RemoveProject(ctx *gin.Context) {
+	collection, ok := c.requireCollectionOwner(ctx)
+	if !ok {
+		return
+	}
+
+	page, err := c.collectionService.RemoveProject(ctx, collection, ctx.Param("projectId"))
+	if err != nil {
+		respondCollectionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// ReorderProjects задает порядок проектов подборки; доступно только автору
+func (c *CollectionController) ReorderProjects(ctx *gin.Context) {
+	collection, ok := c.requireCollectionOwner(ctx)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		ProjectIDs []string `json:"projectIds" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := c.collectionService.ReorderProjects(ctx, collection, request.ProjectIDs)
+	if err != nil {
+		respondCollectionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// requireCollectionOwner загружает подборку :id и проверяет, что ее автор - пользователь из JWT токена.
+// При ошибке ответ клиенту уже отправлен и возвращается false.
+func (c *CollectionController) requireCollectionOwner(ctx *gin.Context) (models.Collection, bool) {
+	userID := ctx.GetString("user_id")
+	collection, err := c.collectionService.GetCollection(ctx, ctx.Param("id"), userID)
+	if err != nil {
+		respondCollectionError(ctx, err)
+		return collection, false
+	}
+
+	if collection.UserID.Hex() != userID {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only change your own collections"})
+		return collection, false
+	}
+
+	return collection, true
+}
+
+// respondCollectionError отправляет ответ, соответствующий ошибке работы с подборкой
+func respondCollectionError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrCollectionNotFound), errors.Is(err, services.ErrProjectUnavailable):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidCollection), errors.Is(err, services.ErrInvalidCollectionOrder):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCollectionLimitReached), errors.Is(err, services.ErrCollectionFull):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"your-project/backend/middleware"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CommentController представляет контроллер комментариев к проектам
+type CommentController struct {
+	commentService *services.CommentService
+}
+
+// NewCommentController создает новый контроллер комментариев
+func NewCommentController(commentService *services.CommentService) *CommentController {
+	return &CommentController{
+		commentService: commentService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты комментариев
+func (c *CommentController) RegisterRoutes(router *gin.RouterGroup) {
+	comments := router.Group("/projects/:id/comments")
+	{
+		comments.GET("", middleware.OptionalAuthMiddleware(), c.GetComments)
+		comments.POST("", middleware.AuthMiddleware(), c.AddComment)
+		comments.GET("/:commentId/replies", middleware.OptionalAuthMiddleware(), c.GetReplies)
+		comments.PUT("/:commentId", middleware.AuthMiddleware(), c.UpdateComment)
+		comments.DELETE("/:commentId", middleware.AuthMiddleware(), c.DeleteComment)
+	}
+}
+
+// GetComments возвращает страницу комментариев верхнего уровня (?cursor=&limit=)
+func (c *CommentController) GetComments(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	page, err := c.commentService.GetComments(ctx, ctx.Param("id"), ctx.GetString("user_id"), ctx.Query("cursor"), limit)
+	if err != nil {
+		respondCommentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// GetReplies возвращает страницу ответов на комментарий (?cursor=&limit=)
+func (c *CommentController) GetReplies(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	page, err := c.commentService.GetReplies(ctx, ctx.Param("id"), ctx.GetString("user_id"), ctx.Param("commentId"), ctx.Query("cursor"), limit)
+	if err != nil {
+		respondCommentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// AddComment добавляет комментарий или ответ (если передан parentId) от имени текущего пользователя
+func (c *CommentController) AddComment(ctx *gin.Context) {
+	var request struct {
+		Content  string `json:"content" binding:"required"`
+		ParentID string `json:"parentId"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := c.commentService.AddComment(ctx, ctx.Param("id"), ctx.GetString("user_id"), request.Content, request.ParentID)
+	if err != nil {
+		respondCommentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, comment)
+}
+
+// UpdateComment изменяет текст комментария текущего пользователя
+func (c *CommentController) UpdateComment(ctx *gin.Context) {
+	var request struct {
+		Content string `json:"content" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := c.commentService.UpdateComment(ctx, ctx.Param("id"), ctx.Param("commentId"), ctx.GetString("user_id"), request.Content)
+	if err != nil {
+		respondCommentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment удаляет комментарий (автором или владельцем проекта) вместе с ответами
+func (c *CommentController) DeleteComment(ctx *gin.Context) {
+	if err := c.commentService.DeleteComment(ctx, ctx.Param("id"), ctx.Param("commentId"), ctx.GetString("user_id")); err != nil {
+		respondCommentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
+
+// respondCommentError отправляет ответ, соответствующий ошибке работы с комментариями
+func respondCommentError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidComment), errors.Is(err, services.ErrInvalidCursor):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommentForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommentNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, mongo.ErrNoDocuments):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
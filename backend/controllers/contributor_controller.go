@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"your-project/backend/middleware"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ContributorController представляет контроллер участников проектов
+type ContributorController struct {
+	contributorService *services.ContributorService
+	projectService     *services.ProjectService
+}
+
+// NewContributorController создает новый контроллер участников проектов
+func NewContributorController(contributorService *services.ContributorService, projectService *services.ProjectService) *ContributorController {
+	return &ContributorController{
+		contributorService: contributorService,
+		projectService:     projectService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты участников проектов
+func (c *ContributorController) RegisterRoutes(router *gin.RouterGroup) {
+	contributors := router.Group("/projects/:id/contributors", middleware.AuthMiddleware())
+	{
+		contributors.POST("", c.Invite)
+		contributors.POST("/accept", c.AcceptInvitation)
+		contributors.PUT("/:userId", c.UpdateRole)
+		contributors.DELETE("/:userId", c.RemoveContributor)
+	}
+	router.GET("/users/:id/invitations", middleware.AuthMiddleware(), c.GetInvitations)
+}
+
+// Invite приглашает пользователя в проект; доступно только владельцу
+func (c *ContributorController) Invite(ctx *gin.Context) {
+	if _, ok := requireProjectOwner(ctx, c.projectService); !ok {
+		return
+	}
+
+	var request struct {
+		UserID string `json:"userId" binding:"required"`
+		Role   string `json:"role" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := c.contributorService.Invite(ctx, ctx.Param("id"), ctx.GetString("user_id"), request.UserID, request.Role)
+	if err != nil {
+		respondContributorError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, project)
+}
+
+// AcceptInvitation принимает приглашение текущего пользователя в проект
+func (c *ContributorController) AcceptInvitation(ctx *gin.Context) {
+	project, err := c.contributorService.AcceptInvitation(ctx, ctx.Param("id"), ctx.GetString("user_id"))
+	if err != nil {
+		respondContributorError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+// UpdateRole изменяет роль участника; доступно только владельцу
+func (c *ContributorController) UpdateRole(ctx *gin.Context) {
+	if _, ok := requireProjectOwner(ctx, c.projectService); !ok {
+		return
+	}
+
+	var request struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := c.contributorService.UpdateRole(ctx, ctx.Param("id"), ctx.Param("userId"), request.Role)
+	if err != nil {
+		respondContributorError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+// RemoveContributor удаляет участника (владельцем) или позволяет пользователю
+// покинуть проект либо отклонить приглашение
+func (c *ContributorController) RemoveContributor(ctx *gin.Context) {
+	if ctx.Param("userId") != ctx.GetString("user_id") {
+		if _, ok := requireProjectOwner(ctx, c.projectService); !ok {
+			return
+		}
+	}
+
+	project, err := c.contributorService.RemoveContributor(ctx, ctx.Param("id"), ctx.Param("userId"))
+	if err != nil {
+		respondContributorError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+// GetInvitations возвращает проекты, в которые приглашен текущий пользователь
+func (c *ContributorController) GetInvitations(ctx *gin.Context) {
+	if ctx.Param("id") != ctx.GetString("user_id") {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only view your own invitations"})
+		return
+	}
+
+	projects, err := c.contributorService.GetInvitations(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, projects)
+}
+
+// respondContributorError отправляет ответ, соответствующий ошибке работы с участниками
+func respondContributorError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidRole):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyContributor):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrContributorNotFound), errors.Is(err, services.ErrInvitationNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, mongo.ErrNoDocuments):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"your-project/backend/middleware"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailController представляет контроллер смены email
+type EmailController struct {
+	emailChangeService *services.EmailChangeService
+}
+
+// NewEmailController создает новый контроллер смены email
+func NewEmailController(emailChangeService *services.EmailChangeService) *EmailController {
+	return &EmailController{emailChangeService}
+}
+
+// RegisterRoutes регистрирует маршруты смены email
+func (c *EmailController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/users/:id/email", middleware.AuthMiddleware(), c.RequestEmailChange)
+
+	auth := router.Group("/auth/email")
+	{
+		auth.GET("/confirm", c.ConfirmEmailChange)
+		auth.GET("/revert", c.RevertEmailChange)
+	}
+}
+
+// RequestEmailChange отправляет ссылку подтверждения на новый адрес
+func (c *EmailController) RequestEmailChange(ctx *gin.Context) {
+	id := ctx.Param("id")
+	userID, exists := ctx.Get("user_id")
+	if !exists || userID != id {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own profile"})
+		return
+	}
+
+	var request struct {
+		Email    string `json:"email" binding:"required"`
+		Password string `json:"password" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := c.emailChangeService.RequestEmailChange(ctx, id, request.Email, request.Password)
+	if err != nil {
+		respondEmailChangeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"message": "Confirmation link sent to the new email address"})
+}
+
+// ConfirmEmailChange подтверждает новый email по ссылке из письма
+func (c *EmailController) ConfirmEmailChange(ctx *gin.Context) {
+	user, err := c.emailChangeService.ConfirmEmailChange(ctx, ctx.Query("token"))
+	if err != nil {
+		respondEmailChangeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Email changed successfully", "email": user.Email})
+}
+
+// RevertEmailChange возвращает предыдущий email по ссылке, отправленной на старый адрес
+func (c *EmailController) RevertEmailChange(ctx *gin.Context) {
+	user, err := c.emailChangeService.RevertEmailChange(ctx, ctx.Query("token"))
+	if err != nil {
+		respondEmailChangeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Email change reverted", "email": user.Email})
+}
+
+// respondEmailChangeError отправляет ответ, соответствующий ошибке смены email
+func respondEmailChangeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidEmail), errors.Is(err, services.ErrSameEmail), errors.Is(err, services.ErrInvalidEmailToken):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrEmailTaken):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidCredentials):
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
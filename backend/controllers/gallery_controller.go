@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"your-project/backend/middleware"
+	"your-project/backend/models"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GalleryController представляет контроллер галереи проекта
+type GalleryController struct {
+	galleryService *services.GalleryService
+	projectService *services.ProjectService
+}
+
+// NewGalleryController создает новый контроллер галереи
+func NewGalleryController(galleryService *services.GalleryService, projectService *services.ProjectService) *GalleryController {
+	return &GalleryController{
+		galleryService: galleryService,
+		projectService: projectService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты галереи проекта
+func (c *GalleryController) RegisterRoutes(router *gin.RouterGroup) {
+	projects := router.Group("/projects/:id", middleware.AuthMiddleware())
+	{
+		projects.POST("/media", c.AddMedia)
+		projects.PUT("/media/order", c.ReorderMedia)
+		projects.PUT("/media/:mediaId", c.UpdateMedia)
+		projects.DELETE("/media/:mediaId", c.RemoveMedia)
+		projects.PUT("/cover", c.SetCover)
+	}
+}
+
+// AddMedia добавляет элемент в галерею: изображение в multipart-поле "file"
+// (с полями "caption" и "altText") или ссылку на изображение/видео в JSON
+func (c *GalleryController) AddMedia(ctx *gin.Context) {
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
+		return
+	}
+	id := ctx.Param("id")
+
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		data, ok := readUploadedImage(ctx)
+		if !ok {
+			return
+		}
+
+		item, err := c.galleryService.AddImage(ctx, id, data, ctx.PostForm("caption"), ctx.PostForm("altText"))
+		if err != nil {
+			respondGalleryError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, item)
+		return
+	}
+
+	var item models.MediaItem
+	if err := ctx.ShouldBindJSON(&item); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := c.galleryService.AddLink(ctx, id, item)
+	if err != nil {
+		respondGalleryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, created)
+}
+
+// UpdateMedia изменяет подпись и альтернативный текст элемента галереи
+func (c *GalleryController) UpdateMedia(ctx *gin.Context) {
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
+		return
+	}
+
+	var request struct {
+		Caption string `json:"caption"`
+		AltText string `json:"altText"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := c.galleryService.UpdateMedia(ctx, ctx.Param("id"), ctx.Param("mediaId"), request.Caption, request.AltText)
+	if err != nil {
+		respondGalleryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+// ReorderMedia задает порядок элементов галереи
+func (c *GalleryController) ReorderMedia(ctx *gin.Context) {
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
+		return
+	}
+
+	var request struct {
+		IDs []string `json:"ids" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := c.galleryService.ReorderMedia(ctx, ctx.Param("id"), request.IDs)
+	if err != nil {
+		respondGalleryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+// RemoveMedia удаляет элемент из галереи
+func (c *GalleryController) RemoveMedia(ctx *gin.Context) {
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
+		return
+	}
+
+	if err := c.galleryService.RemoveMedia(ctx, ctx.Param("id"), ctx.Param("mediaId")); err != nil {
+		respondGalleryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Media item deleted successfully"})
+}
+
+// SetCover делает изображение из галереи обложкой проекта
+func (c *GalleryController) SetCover(ctx *gin.Context) {
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
+		return
+	}
+
+	var request struct {
+		MediaID string `json:"mediaId" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := c.galleryService.SetCover(ctx, ctx.Param("id"), request.MediaID)
+	if err != nil {
+		respondGalleryError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+// respondGalleryError отправляет ответ, соответствующий ошибке работы с галереей
+func respondGalleryError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrMediaNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrGalleryFull):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidMedia), errors.Is(err, services.ErrInvalidMediaOrder):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		respondImageError(ctx, err)
+	}
+}
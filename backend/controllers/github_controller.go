@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"your-project/backend/github"
+	"your-project/backend/middleware"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GitHubController представляет контроллер синхронизации и импорта проектов из GitHub
+type GitHubController struct {
+	syncService    *services.GitHubSyncService
+	importService  *services.GitHubImportService
+	projectService *services.ProjectService
+}
+
+// NewGitHubController создает новый контроллер синхронизации и импорта из GitHub
+func NewGitHubController(syncService *services.GitHubSyncService, importService *services.GitHubImportService, projectService *services.ProjectService) *GitHubController {
+	return &GitHubController{
+		syncService:    syncService,
+		importService:  importService,
+		projectService: projectService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты синхронизации и импорта из GitHub
+func (c *GitHubController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/projects/:id/github/sync", middleware.AuthMiddleware(), c.SyncProject)
+
+	imports := router.Group("/projects/import/github", middleware.AuthMiddleware())
+	{
+		imports.GET("", c.ListRepositories)
+		imports.POST("", c.ImportRepositories)
+	}
+}
+
+// SyncProject обновляет данные репозитория проекта из GitHub по запросу владельца или мейнтейнера
+func (c *GitHubController) SyncProject(ctx *gin.Context) {
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
+		return
+	}
+
+	project, err := c.syncService.SyncProject(ctx, ctx.Param("id"))
+	if err != nil {
+		respondGitHubError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+// ListRepositories возвращает публичные репозитории пользователя GitHub для выбора при импорте
+func (c *GitHubController) ListRepositories(ctx *gin.Context) {
+	repositories, err := c.importService.ListRepositories(ctx, ctx.GetString("user_id"), ctx.Query("username"))
+	if err != nil {
+		respondGitHubError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, repositories)
+}
+
+// ImportRepositories создает проекты текущего пользователя из выбранных репозиториев GitHub
+func (c *GitHubController) ImportRepositories(ctx *gin.Context) {
+	var request struct {
+		Username     string   `json:"username" binding:"required"`
+		Repositories []string `json:"repositories" binding:"required"`
+		Status       string   `json:"status"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := c.importService.Import(ctx, ctx.GetString("user_id"), request.Username, request.Repositories, request.Status)
+	if err != nil {
+		respondGitHubError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+// respondGitHubError отвечает на ошибку синхронизации или импорта подходящим HTTP-статусом
+func respondGitHubError(ctx *gin.Context, err error) {
+	var rateLimit *github.RateLimitError
+	switch {
+	case errors.As(err, &rateLimit):
+		retryAfter := int(time.Until(rateLimit.ResetAt).Seconds()) + 1
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNoGitHubURL), errors.Is(err, github.ErrInvalidRepositoryURL),
+		errors.Is(err, github.ErrInvalidUsername), errors.Is(err, services.ErrInvalidGitHubImport),
+		isInvalidProject(err):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, github.ErrRepositoryNotFound), errors.Is(err, github.ErrUserNotFound):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	}
+}
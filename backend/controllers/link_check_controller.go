@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"your-project/backend/middleware"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// LinkCheckController представляет контроллер проверки ссылок профиля и проектов
+type LinkCheckController struct {
+	linkCheckService *services.LinkCheckService
+}
+
+// NewLinkCheckController создает новый контроллер проверки ссылок
+func NewLinkCheckController(linkCheckService *services.LinkCheckService) *LinkCheckController {
+	return &LinkCheckController{
+		linkCheckService: linkCheckService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты проверки ссылок
+func (c *LinkCheckController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/:id/links", middleware.AuthMiddleware(), c.GetLinks)
+	router.POST("/users/:id/links/check", middleware.AuthMiddleware(), c.CheckLinks)
+}
+
+// GetLinks возвращает владельцу профиля состояние его ссылок, начиная с неработающих
+func (c *LinkCheckController) GetLinks(ctx *gin.Context) {
+	if !requireLinkOwner(ctx) {
+		return
+	}
+
+	report, err := c.linkCheckService.GetReport(ctx, ctx.Param("id"))
+	if err != nil {
+		respondLinkCheckError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// CheckLinks сразу проверяет все ссылки профиля и проектов владельца
+func (c *LinkCheckController) CheckLinks(ctx *gin.Context) {
+	if !requireLinkOwner(ctx) {
+		return
+	}
+
+	report, err := c.linkCheckService.CheckUser(ctx, ctx.Param("id"))
+	if err != nil {
+		respondLinkCheckError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// requireLinkOwner проверяет, что пользователь запрашивает ссылки собственного профиля
+func requireLinkOwner(ctx *gin.Context) bool {
+	if ctx.GetString("user_id") != ctx.Param("id") {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only view links of your own profile"})
+		return false
+	}
+	return true
+}
+
+// respondLinkCheckError отправляет ответ об ошибке получения состояния ссылок
+func respondLinkCheckError(ctx *gin.Context, err error) {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	var rateLimit *services.LinkCheckRateLimitError
+	if errors.As(err, &rateLimit) {
+		retryAfter := int(time.Until(rateLimit.RetryAt).Seconds()) + 1
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
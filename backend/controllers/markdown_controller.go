@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"net/http"
+
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MarkdownController представляет контроллер ресурсов для отображения Markdown
+type MarkdownController struct{}
+
+// NewMarkdownController создает новый контроллер Markdown
+func NewMarkdownController() *MarkdownController {
+	return &MarkdownController{}
+}
+
+// RegisterRoutes регистрирует маршруты Markdown
+func (c *MarkdownController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/markdown/styles.css", c.GetStylesheet)
+}
+
+// GetStylesheet возвращает CSS подсветки синтаксиса для блоков кода в отрендеренном HTML
+func (c *MarkdownController) GetStylesheet(ctx *gin.Context) {
+	css, err := services.MarkdownStylesheet()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=86400")
+	ctx.Data(http.StatusOK, "text/css; charset=utf-8", []byte(css))
+}
@@ -2,7 +2,9 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"your-project/backend/middleware"
 	"your-project/backend/models"
@@ -14,12 +16,16 @@ import (
 
 // ProjectController представляет контроллер для работы с проектами
 type ProjectController struct {
-	projectService *services.ProjectService
+	projectService   *services.ProjectService
+	analyticsService *services.AnalyticsService
 }
 
 // NewProjectController создает новый контроллер проектов
-func NewProjectController(projectService *services.ProjectService) *ProjectController {
-	return &ProjectController{projectService}
+func NewProjectController(projectService *services.ProjectService, analyticsService *services.AnalyticsService) *ProjectController {
+	return &ProjectController{
+		projectService:   projectService,
+		analyticsService: analyticsService,
+	}
 }
 
 // RegisterRoutes регистрирует маршруты для проектов
@@ -27,17 +33,30 @@ func (c *ProjectController) RegisterRoutes(router *gin.RouterGroup) {
 	projects := router.Group("/projects")
 	{
 		projects.GET("", c.GetAllProjects)
-		projects.GET("/:id", c.GetProjectByID)
+		projects.GET("/browse", c.BrowseProjects)
+		projects.GET("/:id", middleware.OptionalAuthMiddleware(), c.GetProjectByID)
 		projects.POST("", middleware.AuthMiddleware(), c.CreateProject)
 		projects.PUT("/:id", middleware.AuthMiddleware(), c.UpdateProject)
+		projects.PATCH("/:id", middleware.AuthMiddleware(), c.PatchProject)
+		projects.POST("/:id/image", middleware.AuthMiddleware(), c.UploadProjectImage)
 		projects.DELETE("/:id", middleware.AuthMiddleware(), c.DeleteProject)
-		projects.GET("/user/:userId", c.GetUserProjects)
+		projects.POST("/:id/transfer", middleware.AuthMiddleware(), c.TransferOwnership)
+		projects.GET("/user/:userId", middleware.OptionalAuthMiddleware(), c.GetUserProjects)
+		projects.POST("/user/:userId/pins", middleware.AuthMiddleware(), c.PinProject)
+		projects.DELETE("/user/:userId/pins/:projectId", middleware.AuthMiddleware(), c.UnpinProject)
+		projects.PUT("/user/:userId/order", middleware.AuthMiddleware(), c.ReorderProjects)
 	}
 }
 
-// GetAllProjects возвращает все проекты
+// GetAllProjects возвращает все проекты; с параметром sort=stars - начиная с самых отмеченных
 func (c *ProjectController) GetAllProjects(ctx *gin.Context) {
-	projects, err := c.projectService.GetAllProjects(ctx)
+	var projects []models.Project
+	var err error
+	if ctx.Query("sort") == "stars" {
+		projects, err = c.projectService.GetProjectsByStars(ctx)
+	} else {
+		projects, err = c.projectService.GetAllProjects(ctx)
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -46,6 +65,26 @@ func (c *ProjectController) GetAllProjects(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, projects)
 }
 
+// BrowseProjects возвращает страницу каталога проектов с фасетами. Фильтры category, tag и
+// technology можно повторять: подходят проекты любой из категорий со всеми тегами и технологиями.
+func (c *ProjectController) BrowseProjects(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.Query("page"))
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+	filter := models.ProjectFilter{
+		Categories:   ctx.QueryArray("category"),
+		Tags:         ctx.QueryArray("tag"),
+		Technologies: ctx.QueryArray("technology"),
+	}
+
+	result, err := c.projectService.BrowseProjects(ctx, filter, page, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
 // GetProjectByID возвращает проект по ID
 func (c *ProjectController) GetProjectByID(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -55,6 +94,14 @@ func (c *ProjectController) GetProjectByID(ctx *gin.Context) {
 		return
 	}
 
+	// Черновики и архивные проекты доступны только участникам
+	if !project.IsVisibleTo(ctx.GetString("user_id")) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	c.analyticsService.TrackView(models.ViewTargetProject, project.ID, project.UserID, visitorFromRequest(ctx))
+
 	ctx.JSON(http.StatusOK, project)
 }
 
@@ -82,6 +129,10 @@ func (c *ProjectController) CreateProject(ctx *gin.Context) {
 	project.UserID = objectID
 
 	createdProject, err := c.projectService.CreateProject(ctx, project)
+	if isInvalidProject(err) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -93,88 +144,121 @@ func (c *ProjectController) CreateProject(ctx *gin.Context) {
 // UpdateProject обновляет проект
 func (c *ProjectController) UpdateProject(ctx *gin.Context) {
 	id := ctx.Param("id")
-	
-	// Получить проект для проверки владельца
-	project, err := c.projectService.GetProjectByID(ctx, id)
-	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	project, ok := requireProjectEditor(ctx, c.projectService)
+	if !ok {
 		return
 	}
 
-	// Получить ID пользователя из JWT токена
-	userID, exists := ctx.Get("user_id")
-	if !exists {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in token"})
+	// Привязать JSON к модели проекта
+	var updatedProject models.Project
+	if err := ctx.ShouldBindJSON(&updatedProject); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Проверить, что пользователь является владельцем проекта
-	if project.UserID.Hex() != userID.(string) {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own projects"})
+	// Владелец не меняется при обновлении, в том числе мейнтейнером
+	updatedProject.UserID = project.UserID
+
+	err := c.projectService.UpdateProject(ctx, id, updatedProject, ctx.GetString("user_id"))
+	if isInvalidProject(err) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Привязать JSON к модели проекта
-	var updatedProject models.Project
-	if err := ctx.ShouldBindJSON(&updatedProject); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	ctx.JSON(http.StatusOK, gin.H{"message": "Project updated successfully"})
+}
+
+// PatchProject частично обновляет проект (JSON Merge Patch)
+func (c *ProjectController) PatchProject(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
 		return
 	}
 
-	// Установить ID пользователя
-	objectID, err := primitive.ObjectIDFromHex(userID.(string))
+	patch, ok := readMergePatch(ctx)
+	if !ok {
+		return
+	}
+
+	updatedProject, err := c.projectService.PatchProject(ctx, id, patch, ctx.GetString("user_id"))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		respondPatchError(ctx, err)
 		return
 	}
-	updatedProject.UserID = objectID
 
-	err = c.projectService.UpdateProject(ctx, id, updatedProject)
+	ctx.JSON(http.StatusOK, updatedProject)
+}
+
+// UploadProjectImage загружает изображение проекта
+func (c *ProjectController) UploadProjectImage(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
+		return
+	}
+
+	data, ok := readUploadedImage(ctx)
+	if !ok {
+		return
+	}
+
+	updatedProject, err := c.projectService.UploadProjectImage(ctx, id, data)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondImageError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Project updated successfully"})
+	ctx.JSON(http.StatusOK, updatedProject)
 }
 
 // DeleteProject удаляет проект
 func (c *ProjectController) DeleteProject(ctx *gin.Context) {
-	id := ctx.Param("id")
-	
-	// Получить проект для проверки владельца
-	project, err := c.projectService.GetProjectByID(ctx, id)
-	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	if _, ok := requireProjectOwner(ctx, c.projectService); !ok {
 		return
 	}
 
-	// Получить ID пользователя из JWT токена
-	userID, exists := ctx.Get("user_id")
-	if !exists {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in token"})
+	if err := c.projectService.DeleteProject(ctx, ctx.Param("id")); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
+}
+
+// TransferOwnership передает проект участнику; доступно только владельцу
+func (c *ProjectController) TransferOwnership(ctx *gin.Context) {
+	if _, ok := requireProjectOwner(ctx, c.projectService); !ok {
 		return
 	}
 
-	// Проверить, что пользователь является владельцем проекта
-	if project.UserID.Hex() != userID.(string) {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only delete your own projects"})
+	var request struct {
+		UserID string `json:"userId" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	err = c.projectService.DeleteProject(ctx, id)
+	project, err := c.projectService.TransferOwnership(ctx, ctx.Param("id"), request.UserID)
 	if err != nil {
+		if errors.Is(err, services.ErrContributorNotFound) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "The new owner must be a contributor who accepted the invitation"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
+	ctx.JSON(http.StatusOK, project)
 }
 
 // GetUserProjects возвращает проекты пользователя
 func (c *ProjectController) GetUserProjects(ctx *gin.Context) {
 	userID := ctx.Param("userId")
-	projects, err := c.projectService.GetUserProjects(ctx, userID)
+	projects, err := c.projectService.GetUserProjects(ctx, userID, ctx.GetString("user_id"))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -182,3 +266,95 @@ func (c *ProjectController) GetUserProjects(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, projects)
 }
+
+// PinProject закрепляет проект в профиле; доступно только владельцу профиля
+func (c *ProjectController) PinProject(ctx *gin.Context) {
+	if !requireProfileOwner(ctx) {
+		return
+	}
+
+	var request struct {
+		ProjectID string `json:"projectId" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	projects, err := c.projectService.PinProject(ctx, ctx.Param("userId"), request.ProjectID)
+	if err != nil {
+		respondProfileLayoutError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, projects)
+}
+
+// UnpinProject снимает закрепление проекта в профиле; доступно только владельцу профиля
+func (c *ProjectController) UnpinProject(ctx *gin.Context) {
+	if !requireProfileOwner(ctx) {
+		return
+	}
+
+	projects, err := c.projectService.UnpinProject(ctx, ctx.Param("userId"), ctx.Param("projectId"))
+	if err != nil {
+		respondProfileLayoutError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, projects)
+}
+
+// ReorderProjects задает порядок проектов в профиле; доступно только владельцу профиля
+func (c *ProjectController) ReorderProjects(ctx *gin.Context) {
+	if !requireProfileOwner(ctx) {
+		return
+	}
+
+	var request struct {
+		ProjectIDs []string `json:"projectIds" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	projects, err := c.projectService.ReorderProjects(ctx, ctx.Param("userId"), request.ProjectIDs)
+	if err != nil {
+		respondProfileLayoutError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, projects)
+}
+
+// requireProfileOwner проверяет, что пользователь меняет собственный профиль
+func requireProfileOwner(ctx *gin.Context) bool {
+	if ctx.GetString("user_id") != ctx.Param("userId") {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only arrange projects on your own profile"})
+		return false
+	}
+	return true
+}
+
+// respondProfileLayoutError отправляет ответ об ошибке закрепления или упорядочивания проектов
+func respondProfileLayoutError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProjectNotOnProfile):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrPinLimitReached):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidProjectOrder):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// isInvalidProject проверяет, вызвана ли ошибка некорректными данными проекта
+func isInvalidProject(err error) bool {
+	return errors.Is(err, services.ErrInvalidProjectStatus) ||
+		errors.Is(err, services.ErrUnknownCategory) ||
+		errors.Is(err, services.ErrInvalidTags) ||
+		errors.Is(err, services.ErrInvalidCaseStudy)
+}
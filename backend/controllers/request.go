@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"your-project/backend/models"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// visitorFromRequest собирает данные посетителя для учета просмотров
+func visitorFromRequest(ctx *gin.Context) services.Visitor {
+	return services.Visitor{
+		UserID:    ctx.GetString("user_id"),
+		IP:        ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+		Referrer:  ctx.Request.Referer(),
+	}
+}
+
+// requireProjectEditor проверяет, что пользователь из JWT токена может редактировать проект :id
+// (владелец или мейнтейнер). При ошибке ответ клиенту уже отправлен и возвращается false.
+func requireProjectEditor(ctx *gin.Context, projectService *services.ProjectService) (models.Project, bool) {
+	project, userID, ok := projectWithUser(ctx, projectService)
+	if !ok {
+		return project, false
+	}
+
+	// Проверить, что пользователь является владельцем или мейнтейнером проекта
+	if !project.CanEdit(userID) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only update projects you own or maintain"})
+		return project, false
+	}
+
+	return project, true
+}
+
+// requireProjectOwner проверяет, что пользователь из JWT токена является владельцем проекта :id.
+// При ошибке ответ клиенту уже отправлен и возвращается false.
+func requireProjectOwner(ctx *gin.Context, projectService *services.ProjectService) (models.Project, bool) {
+	project, userID, ok := projectWithUser(ctx, projectService)
+	if !ok {
+		return project, false
+	}
+
+	// Проверить, что пользователь является владельцем проекта
+	if project.RoleOf(userID) != models.RoleOwner {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Only the project owner can do this"})
+		return project, false
+	}
+
+	return project, true
+}
+
+// projectWithUser загружает проект :id и ID пользователя из JWT токена
+func projectWithUser(ctx *gin.Context, projectService *services.ProjectService) (models.Project, string, bool) {
+	// Получить проект для проверки прав
+	project, err := projectService.GetProjectByID(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return project, "", false
+	}
+
+	// Получить ID пользователя из JWT токена
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in token"})
+		return project, "", false
+	}
+
+	return project, userID.(string), true
+}
+
+// maxPatchSize максимальный размер тела PATCH-запроса (1 МБ)
+const maxPatchSize = 1 << 20
+
+// readMergePatch читает тело запроса с JSON Merge Patch.
+// При ошибке ответ клиенту уже отправлен и возвращается false.
+func readMergePatch(ctx *gin.Context) ([]byte, bool) {
+	contentType := ctx.ContentType()
+	if contentType != services.MIMEMergePatch && contentType != gin.MIMEJSON {
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be " + services.MIMEMergePatch})
+		return nil, false
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxPatchSize))
+	if err != nil {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body is too large"})
+		return nil, false
+	}
+
+	return patch, true
+}
+
+// respondPatchError отправляет ответ, соответствующий ошибке применения патча
+func respondPatchError(ctx *gin.Context, err error) {
+	if errors.Is(err, services.ErrInvalidPatch) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"your-project/backend/middleware"
+	"your-project/backend/models"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxJSONResumeSize максимальный размер импортируемого документа JSON Resume (1 МБ)
+const maxJSONResumeSize = 1 << 20
+
+// unsafeFilenameChars символы, которые заменяются в имени скачиваемого файла
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// ResumeController представляет контроллер для формирования, импорта и экспорта резюме
+type ResumeController struct {
+	resumeService     *services.ResumeService
+	jsonResumeService *services.JSONResumeService
+}
+
+// NewResumeController создает новый контроллер резюме
+func NewResumeController(resumeService *services.ResumeService, jsonResumeService *services.JSONResumeService) *ResumeController {
+	return &ResumeController{
+		resumeService:     resumeService,
+		jsonResumeService: jsonResumeService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты для резюме
+func (c *ResumeController) RegisterRoutes(router *gin.RouterGroup) {
+	users := router.Group("/users")
+	{
+		users.GET("/:id/resume.pdf", middleware.OptionalAuthMiddleware(), c.GetResume)
+		users.GET("/:id/resume.json", middleware.OptionalAuthMiddleware(), c.ExportJSONResume)
+		users.POST("/:id/resume.json", middleware.AuthMiddleware(), c.ImportJSONResume)
+	}
+}
+
+// GetResume возвращает PDF-резюме пользователя
+func (c *ResumeController) GetResume(ctx *gin.Context) {
+	id := ctx.Param("id")
+	viewerID := ctx.GetString("user_id")
+
+	pdf, err := c.resumeService.GenerateResume(ctx, id, viewerID, ctx.Query("template"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnknownResumeTemplate):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "templates": services.ResumeTemplates()})
+		case errors.Is(err, services.ErrResumeDisabled):
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		}
+		return
+	}
+
+	filename := unsafeFilenameChars.ReplaceAllString("resume-"+id, "_") + ".pdf"
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	ctx.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// ExportJSONResume возвращает профиль пользователя в формате JSON Resume
+func (c *ResumeController) ExportJSONResume(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	resume, err := c.jsonResumeService.Export(ctx, id, ctx.GetString("user_id"))
+	if err != nil {
+		if errors.Is(err, services.ErrResumeDisabled) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resume)
+}
+
+// ImportJSONResume обновляет профиль и проекты пользователя из документа JSON Resume.
+// Документ передается в теле запроса или как поле "file" multipart-формы; ?dryRun=true только показывает изменения.
+func (c *ResumeController) ImportJSONResume(ctx *gin.Context) {
+	id := ctx.Param("id")
+	userID, exists := ctx.Get("user_id")
+	if !exists || userID != id {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own profile"})
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(ctx.Query("dryRun"))
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxJSONResumeSize)
+
+	var body io.Reader = ctx.Request.Body
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		file, _, err := ctx.Request.FormFile(uploadFormField)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Field 'file' with a JSON Resume document is required"})
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	var resume models.JSONResume
+	if err := json.NewDecoder(body).Decode(&resume); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON Resume document: " + err.Error()})
+		return
+	}
+
+	result, err := c.jsonResumeService.Import(ctx, id, resume, dryRun)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidJSONResume) || errors.Is(err, services.ErrInvalidPatch) || isInvalidProject(err) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
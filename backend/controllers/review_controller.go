@@ -30,6 +30,7 @@ func (c *ReviewController) RegisterRoutes(router *gin.RouterGroup) {
 		reviews.GET("/:id", c.GetReviewByID)
 		reviews.POST("", middleware.AuthMiddleware(), c.CreateReview)
 		reviews.PUT("/:id", middleware.AuthMiddleware(), c.UpdateReview)
+		reviews.PATCH("/:id", middleware.AuthMiddleware(), c.PatchReview)
 		reviews.DELETE("/:id", middleware.AuthMiddleware(), c.DeleteReview)
 		reviews.GET("/user/:userId", c.GetUserReviews)
 	}
@@ -140,6 +141,44 @@ func (c *ReviewController) UpdateReview(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Review updated successfully"})
 }
 
+// PatchReview частично обновляет отзыв (JSON Merge Patch)
+func (c *ReviewController) PatchReview(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	// Получить отзыв для проверки владельца
+	review, err := c.reviewService.GetReviewByID(ctx, id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		return
+	}
+
+	// Получить ID пользователя из JWT токена
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in token"})
+		return
+	}
+
+	// Проверить, что пользователь является автором отзыва
+	if review.ReviewerID.Hex() != userID.(string) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own reviews"})
+		return
+	}
+
+	patch, ok := readMergePatch(ctx)
+	if !ok {
+		return
+	}
+
+	updatedReview, err := c.reviewService.PatchReview(ctx, id, patch)
+	if err != nil {
+		respondPatchError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updatedReview)
+}
+
 // DeleteReview удаляет отзыв
 func (c *ReviewController) DeleteReview(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"your-project/backend/middleware"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RevisionController представляет контроллер истории версий проектов
+type RevisionController struct {
+	projectService *services.ProjectService
+}
+
+// NewRevisionController создает новый контроллер истории версий проектов
+func NewRevisionController(projectService *services.ProjectService) *RevisionController {
+	return &RevisionController{
+		projectService: projectService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты истории версий проектов
+func (c *RevisionController) RegisterRoutes(router *gin.RouterGroup) {
+	revisions := router.Group("/projects/:id/revisions", middleware.AuthMiddleware())
+	{
+		revisions.GET("", c.GetRevisions)
+		revisions.GET("/diff", c.DiffRevisions)
+		revisions.GET("/:number", c.GetRevision)
+		revisions.POST("/:number/restore", c.RestoreRevision)
+	}
+}
+
+// GetRevisions возвращает историю версий проекта владельцу и мейнтейнерам
+func (c *RevisionController) GetRevisions(ctx *gin.Context) {
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
+		return
+	}
+
+	revisions, err := c.projectService.GetRevisions(ctx, ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, revisions)
+}
+
+// GetRevision возвращает версию проекта
+func (c *RevisionController) GetRevision(ctx *gin.Context) {
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
+		return
+	}
+	number, ok := revisionNumber(ctx)
+	if !ok {
+		return
+	}
+
+	revision, err := c.projectService.GetRevision(ctx, ctx.Param("id"), number)
+	if err != nil {
+		respondRevisionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, revision)
+}
+
+// DiffRevisions возвращает изменения полей между версиями ?from=&to=
+// (по умолчанию - между последней версией и предыдущей)
+func (c *RevisionController) DiffRevisions(ctx *gin.Context) {
+	if _, ok := requireProjectEditor(ctx, c.projectService); !ok {
+		return
+	}
+
+	from, err := strconv.Atoi(ctx.DefaultQuery("from", "0"))
+	if err != nil || from < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'from' must be a revision number"})
+		return
+	}
+	to, err := strconv.Atoi(ctx.DefaultQuery("to", "0"))
+	if err != nil || to < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Parameter 'to' must be a revision number"})
+		return
+	}
+
+	diff, err := c.projectService.DiffRevisions(ctx, ctx.Param("id"), from, to)
+	if err != nil {
+		respondRevisionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, diff)
+}
+
+// RestoreRevision возвращает проект к версии; доступно только владельцу
+func (c *RevisionController) RestoreRevision(ctx *gin.Context) {
+	if _, ok := requireProjectOwner(ctx, c.projectService); !ok {
+		return
+	}
+	number, ok := revisionNumber(ctx)
+	if !ok {
+		return
+	}
+
+	project, err := c.projectService.RestoreRevision(ctx, ctx.Param("id"), number, ctx.GetString("user_id"))
+	if err != nil {
+		respondRevisionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+// revisionNumber разбирает номер версии из пути. При ошибке ответ клиенту уже отправлен.
+func revisionNumber(ctx *gin.Context) (int, bool) {
+	number, err := strconv.Atoi(ctx.Param("number"))
+	if err != nil || number < 1 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision number"})
+		return 0, false
+	}
+	return number, true
+}
+
+// respondRevisionError отправляет ответ об ошибке работы с версиями
+func respondRevisionError(ctx *gin.Context, err error) {
+	if errors.Is(err, services.ErrRevisionNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -11,15 +11,17 @@ import (
 
 // SearchController представляет контроллер для поиска
 type SearchController struct {
-	userService    *services.UserService
-	projectService *services.ProjectService
+	userService         *services.UserService
+	projectService      *services.ProjectService
+	completenessService *services.CompletenessService
 }
 
 // NewSearchController создает новый контроллер поиска
-func NewSearchController(userService *services.UserService, projectService *services.ProjectService) *SearchController {
+func NewSearchController(userService *services.UserService, projectService *services.ProjectService, completenessService *services.CompletenessService) *SearchController {
 	return &SearchController{
-		userService:    userService,
-		projectService: projectService,
+		userService:         userService,
+		projectService:      projectService,
+		completenessService: completenessService,
 	}
 }
 
@@ -65,6 +67,17 @@ func (c *SearchController) Search(ctx *gin.Context) {
 		}
 	}
 
+	if ctx.Query("sort") == "completeness" {
+		if err := c.completenessService.SortByCompleteness(ctx, users); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	for i := range users {
+		users[i] = users[i].PublicView()
+	}
+
 	// Поиск по проектам
 	projects, err := c.projectService.SearchProjects(ctx, query)
 	if err != nil {
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// socialCardMaxAge время, в течение которого клиенты и мессенджеры могут не перепроверять карточку
+const socialCardMaxAge = "public, max-age=3600"
+
+// SocialCardController представляет контроллер карточек Open Graph
+type SocialCardController struct {
+	socialCardService *services.SocialCardService
+}
+
+// NewSocialCardController создает новый контроллер карточек
+func NewSocialCardController(socialCardService *services.SocialCardService) *SocialCardController {
+	return &SocialCardController{
+		socialCardService: socialCardService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты карточек
+func (c *SocialCardController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/:id/card.png", c.GetUserCard)
+	router.GET("/projects/:id/card.png", c.GetProjectCard)
+}
+
+// GetUserCard возвращает PNG-карточку профиля
+func (c *SocialCardController) GetUserCard(ctx *gin.Context) {
+	card, err := c.socialCardService.UserCard(ctx, ctx.Param("id"))
+	c.writeCard(ctx, card, err)
+}
+
+// GetProjectCard возвращает PNG-карточку проекта
+func (c *SocialCardController) GetProjectCard(ctx *gin.Context) {
+	card, err := c.socialCardService.ProjectCard(ctx, ctx.Param("id"))
+	c.writeCard(ctx, card, err)
+}
+
+// writeCard отправляет карточку; хэш содержимого используется как ETag
+func (c *SocialCardController) writeCard(ctx *gin.Context, card services.SocialCard, err error) {
+	if errors.Is(err, services.ErrSocialCardNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	etag := `"` + card.Hash + `"`
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", socialCardMaxAge)
+	if strings.Contains(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	data, err := c.socialCardService.Image(ctx, card)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.Data(http.StatusOK, "image/png", data)
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"your-project/backend/middleware"
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// StarController представляет контроллер отметок проектов
+type StarController struct {
+	starService *services.StarService
+}
+
+// NewStarController создает новый контроллер отметок
+func NewStarController(starService *services.StarService) *StarController {
+	return &StarController{
+		starService: starService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты отметок
+func (c *StarController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/projects/:id/star", middleware.AuthMiddleware(), c.IsStarred)
+	router.POST("/projects/:id/star", middleware.AuthMiddleware(), c.StarProject)
+	router.DELETE("/projects/:id/star", middleware.AuthMiddleware(), c.UnstarProject)
+	router.GET("/projects/:id/stargazers", middleware.OptionalAuthMiddleware(), c.GetStargazers)
+	router.GET("/users/:id/starred", c.GetStarredProjects)
+}
+
+// IsStarred сообщает, отметил ли текущий пользователь проект
+func (c *StarController) IsStarred(ctx *gin.Context) {
+	starred, err := c.starService.IsStarred(ctx, ctx.Param("id"), ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"starred": starred})
+}
+
+// StarProject отмечает проект от имени текущего пользователя
+func (c *StarController) StarProject(ctx *gin.Context) {
+	project, err := c.starService.StarProject(ctx, ctx.Param("id"), ctx.GetString("user_id"))
+	if err != nil {
+		respondStarError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+// UnstarProject снимает отметку текущего пользователя с проекта
+func (c *StarController) UnstarProject(ctx *gin.Context) {
+	project, err := c.starService.UnstarProject(ctx, ctx.Param("id"), ctx.GetString("user_id"))
+	if err != nil {
+		respondStarError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, project)
+}
+
+// GetStargazers возвращает пользователей, отметивших проект
+func (c *StarController) GetStargazers(ctx *gin.Context) {
+	stars, err := c.starService.GetStargazers(ctx, ctx.Param("id"), ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stars)
+}
+
+// GetStarredProjects возвращает проекты, отмеченные пользователем
+func (c *StarController) GetStarredProjects(ctx *gin.Context) {
+	projects, err := c.starService.GetStarredProjects(ctx, ctx.Param("id"))
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, projects)
+}
+
+// respondStarError отправляет ответ, соответствующий ошибке работы с отметками
+func respondStarError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrAlreadyStarred), errors.Is(err, services.ErrNotStarred):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, mongo.ErrNoDocuments):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TechnologyController представляет контроллер автодополнения технологий
+type TechnologyController struct {
+	technologyService *services.TechnologyService
+}
+
+// NewTechnologyController создает новый контроллер технологий
+func NewTechnologyController(technologyService *services.TechnologyService) *TechnologyController {
+	return &TechnologyController{
+		technologyService: technologyService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты технологий
+func (c *TechnologyController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/technologies", c.GetTechnologies)
+}
+
+// GetTechnologies возвращает подсказки технологий (?prefix=re&limit=10)
+func (c *TechnologyController) GetTechnologies(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	technologies, err := c.technologyService.Suggest(ctx, ctx.Query("prefix"), limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, technologies)
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrendingController представляет контроллер популярных проектов
+type TrendingController struct {
+	trendingService *services.TrendingService
+}
+
+// NewTrendingController создает новый контроллер популярных проектов
+func NewTrendingController(trendingService *services.TrendingService) *TrendingController {
+	return &TrendingController{
+		trendingService: trendingService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты популярных проектов
+func (c *TrendingController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/projects/trending", c.GetTrending)
+}
+
+// GetTrending возвращает популярные проекты (?technology=Go&limit=20)
+func (c *TrendingController) GetTrending(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+
+	list, err := c.trendingService.GetTrending(ctx, ctx.Query("technology"), limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, list)
+}
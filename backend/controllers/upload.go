@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"your-project/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// uploadFormField имя поля multipart-формы с загружаемым файлом
+const uploadFormField = "file"
+
+// readUploadedImage читает изображение из multipart-формы, ограничивая размер запроса.
+// При ошибке ответ клиенту уже отправлен и возвращается false.
+func readUploadedImage(ctx *gin.Context) ([]byte, bool) {
+	// Запас в 1 МБ на заголовки и остальные части формы
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, services.MaxImageUploadSize+1<<20)
+
+	file, _, err := ctx.Request.FormFile(uploadFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large"})
+			return nil, false
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Field 'file' with an image is required"})
+		return nil, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, services.MaxImageUploadSize+1))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	if len(data) > services.MaxImageUploadSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large"})
+		return nil, false
+	}
+
+	// Тип определяем по содержимому, а не по заголовку Content-Type от клиента
+	if _, err := services.DetectImageType(data); err != nil {
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Only JPEG, PNG, GIF and WebP images are allowed"})
+		return nil, false
+	}
+
+	return data, true
+}
+
+// respondImageError отправляет ответ, соответствующий ошибке обработки изображения
+func respondImageError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrUnsupportedImageType):
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrImageTooLarge):
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
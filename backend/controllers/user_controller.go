@@ -2,24 +2,42 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"your-project/backend/middleware"
 	"your-project/backend/models"
 	"your-project/backend/services"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // UserController представляет контроллер для работы с пользователями
 type UserController struct {
-	userService *services.UserService
+	userService         *services.UserService
+	completenessService *services.CompletenessService
+	analyticsService    *services.AnalyticsService
+	baseURL             string
 }
 
-// NewUserController создает новый контроллер пользователей
-func NewUserController(userService *services.UserService) *UserController {
-	return &UserController{userService}
+// NewUserController создает новый контроллер пользователей.
+// baseURL - внешний адрес API, от которого строятся ссылки на профиль в vCard, h-card и JSON-LD.
+func NewUserController(userService *services.UserService, completenessService *services.CompletenessService, analyticsService *services.AnalyticsService, baseURL string) *UserController {
+	return &UserController{
+		userService:         userService,
+		completenessService: completenessService,
+		analyticsService:    analyticsService,
+		baseURL:             strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// userWithCompleteness представляет профиль пользователя вместе с оценкой его заполненности
+type userWithCompleteness struct {
+	models.User
+	Completeness models.ProfileCompleteness `json:"completeness"`
 }
 
 // RegisterRoutes регистрирует маршруты для пользователей
@@ -27,9 +45,14 @@ func (c *UserController) RegisterRoutes(router *gin.RouterGroup) {
 	users := router.Group("/users")
 	{
 		users.GET("", c.GetAllUsers)
-		users.GET("/:id", c.GetUserByID)
+		users.GET("/:id", middleware.OptionalAuthMiddleware(), c.GetUserByID)
+		users.GET("/:id/vcard", middleware.OptionalAuthMiddleware(), c.GetUserVCard)
+		users.GET("/:id/hcard", middleware.OptionalAuthMiddleware(), c.GetUserHCard)
+		users.GET("/:id/jsonld", middleware.OptionalAuthMiddleware(), c.GetUserJSONLD)
 		users.POST("", c.CreateUser)
 		users.PUT("/:id", middleware.AuthMiddleware(), c.UpdateUser)
+		users.PATCH("/:id", middleware.AuthMiddleware(), c.PatchUser)
+		users.POST("/:id/avatar", middleware.AuthMiddleware(), c.UploadAvatar)
 		users.DELETE("/:id", middleware.AuthMiddleware(), c.DeleteUser)
 	}
 
@@ -48,10 +71,22 @@ func (c *UserController) GetAllUsers(ctx *gin.Context) {
 		return
 	}
 
+	if ctx.Query("sort") == "completeness" {
+		if err := c.completenessService.SortByCompleteness(ctx, users); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	for i := range users {
+		users[i] = users[i].PublicView()
+	}
+
 	ctx.JSON(http.StatusOK, users)
 }
 
-// GetUserByID возвращает пользователя по ID
+// GetUserByID возвращает пользователя по ID.
+// В зависимости от заголовка Accept профиль отдается как JSON, vCard, h-card HTML или JSON-LD.
 func (c *UserController) GetUserByID(ctx *gin.Context) {
 	id := ctx.Param("id")
 	user, err := c.userService.GetUserByID(ctx, id)
@@ -60,7 +95,89 @@ func (c *UserController) GetUserByID(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, user)
+	userID, exists := ctx.Get("user_id")
+	isOwner := exists && userID == id
+
+	c.analyticsService.TrackView(models.ViewTargetUser, user.ID, user.ID, visitorFromRequest(ctx))
+
+	format := ctx.NegotiateFormat(gin.MIMEJSON, services.MIMEVCard, services.MIMEHTML, services.MIMEJSONLD)
+	if format != "" && format != gin.MIMEJSON {
+		if !isOwner {
+			user = user.PublicView()
+		}
+		c.writeProfileFormat(ctx, user, format)
+		return
+	}
+
+	// Владельцу профиля дополнительно показываем оценку заполненности и подсказки
+	if isOwner {
+		completeness, err := c.completenessService.GetCompleteness(ctx, user)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, userWithCompleteness{User: user, Completeness: completeness})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user.PublicView())
+}
+
+// GetUserVCard возвращает профиль пользователя в формате vCard 4.0
+func (c *UserController) GetUserVCard(ctx *gin.Context) {
+	c.getUserInFormat(ctx, services.MIMEVCard)
+}
+
+// GetUserHCard возвращает HTML-страницу профиля с микроразметкой h-card
+func (c *UserController) GetUserHCard(ctx *gin.Context) {
+	c.getUserInFormat(ctx, services.MIMEHTML)
+}
+
+// GetUserJSONLD возвращает профиль пользователя как schema.org Person в формате JSON-LD
+func (c *UserController) GetUserJSONLD(ctx *gin.Context) {
+	c.getUserInFormat(ctx, services.MIMEJSONLD)
+}
+
+// getUserInFormat загружает пользователя с учетом приватности и отдает его в указанном формате
+func (c *UserController) getUserInFormat(ctx *gin.Context, format string) {
+	id := ctx.Param("id")
+	user, err := c.userService.GetUserByID(ctx, id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	if userID, exists := ctx.Get("user_id"); !exists || userID != id {
+		user = user.PublicView()
+	}
+
+	c.writeProfileFormat(ctx, user, format)
+}
+
+// writeProfileFormat отправляет профиль в одном из альтернативных представлений
+func (c *UserController) writeProfileFormat(ctx *gin.Context, user models.User, format string) {
+	profileURL := c.baseURL + "/api/users/" + user.ID.Hex()
+
+	switch format {
+	case services.MIMEVCard:
+		ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", user.ID.Hex()+".vcf"))
+		ctx.Data(http.StatusOK, services.MIMEVCard+"; charset=utf-8", []byte(services.RenderVCard(user, profileURL)))
+	case services.MIMEHTML:
+		page, err := services.RenderHCard(user, profileURL)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Data(http.StatusOK, services.MIMEHTML+"; charset=utf-8", []byte(page))
+	case services.MIMEJSONLD:
+		data, err := json.Marshal(services.RenderJSONLD(user, profileURL))
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Data(http.StatusOK, services.MIMEJSONLD+"; charset=utf-8", data)
+	}
 }
 
 // CreateUser создает нового пользователя
@@ -97,6 +214,10 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 
 	err := c.userService.UpdateUser(ctx, id, user)
 	if err != nil {
+		if errors.Is(err, services.ErrEmailChangeRequiresConfirmation) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -104,6 +225,52 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "User updated successfully"})
 }
 
+// PatchUser частично обновляет пользователя (JSON Merge Patch)
+func (c *UserController) PatchUser(ctx *gin.Context) {
+	id := ctx.Param("id")
+	userID, exists := ctx.Get("user_id")
+	if !exists || userID != id {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own profile"})
+		return
+	}
+
+	patch, ok := readMergePatch(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := c.userService.PatchUser(ctx, id, patch)
+	if err != nil {
+		respondPatchError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+// UploadAvatar загружает новый аватар пользователя
+func (c *UserController) UploadAvatar(ctx *gin.Context) {
+	id := ctx.Param("id")
+	userID, exists := ctx.Get("user_id")
+	if !exists || userID != id {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own profile"})
+		return
+	}
+
+	data, ok := readUploadedImage(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := c.userService.UploadAvatar(ctx, id, data)
+	if err != nil {
+		respondImageError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
 // DeleteUser удаляет пользователя
 func (c *UserController) DeleteUser(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -0,0 +1,108 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrRepositoryNotFound возвращается, если репозиторий не существует или недоступен
+	ErrRepositoryNotFound = errors.New("github repository not found")
+	// ErrInvalidRepositoryURL возвращается, если ссылка не указывает на репозиторий GitHub
+	ErrInvalidRepositoryURL = errors.New("not a github repository url")
+	// ErrUserNotFound возвращается, если пользователя GitHub не существует
+	ErrUserNotFound = errors.New("github user not found")
+	// ErrInvalidUsername возвращается для имени, которое не может быть именем пользователя GitHub
+	ErrInvalidUsername = errors.New("invalid github username")
+)
+
+// RateLimitError возвращается, когда GitHub ограничил частоту запросов
+type RateLimitError struct {
+	// ResetAt момент, после которого можно повторить запрос
+	ResetAt time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("github rate limit exceeded, retry after %s", e.ResetAt.UTC().Format(time.RFC3339))
+}
+
+// Repository представляет метаданные репозитория GitHub
+type Repository struct {
+	FullName string
+	Stars    int
+	Forks    int
+	// Languages языки репозитория в порядке убывания объема кода
+	Languages []string
+	Topics    []string
+	License   string
+	PushedAt  time.Time
+}
+
+// RepositorySummary представляет репозиторий в списке репозиториев пользователя
+type RepositorySummary struct {
+	Name        string
+	FullName    string
+	Description string
+	URL         string // Адрес страницы репозитория на github.com
+	Homepage    string
+	Language    string // Основной язык
+	Topics      []string
+	Stars       int
+	Forks       int
+	Fork        bool
+	Archived    bool
+	PushedAt    time.Time
+}
+
+// Client получает данные репозиториев из GitHub
+type Client interface {
+	// GetRepository возвращает метаданные репозитория owner/name
+	GetRepository(ctx context.Context, owner, name string) (Repository, error)
+	// ListUserRepositories возвращает публичные репозитории пользователя, начиная с недавно обновленных
+	ListUserRepositories(ctx context.Context, username string) ([]RepositorySummary, error)
+}
+
+// usernamePattern допустимые имена пользователей GitHub
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+
+// ValidUsername сообщает, может ли строка быть именем пользователя GitHub
+func ValidUsername(username string) bool {
+	return usernamePattern.MatchString(username)
+}
+
+// repositoryNamePattern допустимые имена владельца и репозитория
+var repositoryNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ParseRepositoryURL извлекает владельца и имя репозитория из ссылки вида
+// https://github.com/owner/name (допускаются суффикс .git и вложенные пути)
+func ParseRepositoryURL(rawURL string) (string, string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", ErrInvalidRepositoryURL
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if host != "github.com" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", "", ErrInvalidRepositoryURL
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) < 2 {
+		return "", "", ErrInvalidRepositoryURL
+	}
+	owner, name := parts[0], strings.TrimSuffix(parts[1], ".git")
+	if !repositoryNamePattern.MatchString(owner) || !repositoryNamePattern.MatchString(name) || name == "." || name == ".." {
+		return "", "", ErrInvalidRepositoryURL
+	}
+
+	return owner, name, nil
+}
@@ -0,0 +1,255 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL адрес GitHub REST API
+	DefaultBaseURL = "https://api.github.com"
+	// apiVersion версия GitHub REST API
+	apiVersion = "2022-11-28"
+	// defaultRetryAfter пауза после ограничения частоты, если GitHub не сообщил время сброса
+	defaultRetryAfter = time.Minute
+	// repositoriesPerPage размер страницы списка репозиториев (максимум GitHub API)
+	repositoriesPerPage = 100
+	// maxRepositoryPages ограничивает количество запрашиваемых страниц списка репозиториев
+	maxRepositoryPages = 10
+)
+
+// HTTPConfig описывает подключение к GitHub REST API
+type HTTPConfig struct {
+	// BaseURL адрес API; по умолчанию DefaultBaseURL (в тестах - адрес локального сервера)
+	BaseURL string
+	// Token персональный токен доступа; без него действует лимит 60 запросов в час
+	Token string
+}
+
+// HTTPClient получает данные репозиториев через GitHub REST API
+type HTTPClient struct {
+	config HTTPConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPClient создает клиент GitHub; если client равен nil, используется http.DefaultClient
+func NewHTTPClient(config HTTPConfig, client *http.Client) *HTTPClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultBaseURL
+	}
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	return &HTTPClient{
+		config: config,
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// repositoryResponse поля ответа GET /repos/{owner}/{repo}
+type repositoryResponse struct {
+	FullName        string    `json:"full_name"`
+	StargazersCount int       `json:"stargazers_count"`
+	ForksCount      int       `json:"forks_count"`
+	Language        string    `json:"language"`
+	Topics          []string  `json:"topics"`
+	PushedAt        time.Time `json:"pushed_at"`
+	License         *struct {
+		SPDXID string `json:"spdx_id"`
+		Name   string `json:"name"`
+	} `json:"license"`
+}
+
+// GetRepository возвращает метаданные репозитория owner/name
+func (c *HTTPClient) GetRepository(ctx context.Context, owner, name string) (Repository, error) {
+	repoPath := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+
+	var response repositoryResponse
+	if err := c.get(ctx, repoPath, &response); err != nil {
+		return Repository{}, err
+	}
+
+	// Объем кода по языкам в байтах
+	var languageBytes map[string]int
+	if err := c.get(ctx, repoPath+"/languages", &languageBytes); err != nil {
+		return Repository{}, err
+	}
+
+	repo := Repository{
+		FullName:  response.FullName,
+		Stars:     response.StargazersCount,
+		Forks:     response.ForksCount,
+		Languages: sortLanguages(languageBytes),
+		Topics:    response.Topics,
+		PushedAt:  response.PushedAt,
+	}
+	if len(repo.Languages) == 0 && response.Language != "" {
+		repo.Languages = []string{response.Language}
+	}
+	if repo.Topics == nil {
+		repo.Topics = []string{}
+	}
+	if response.License != nil {
+		repo.License = response.License.SPDXID
+		if repo.License == "" || repo.License == "NOASSERTION" {
+			repo.License = response.License.Name
+		}
+	}
+
+	return repo, nil
+}
+
+// repositorySummaryResponse элемент ответа GET /users/{username}/repos
+type repositorySummaryResponse struct {
+	Name            string    `json:"name"`
+	FullName        string    `json:"full_name"`
+	Description     string    `json:"description"`
+	HTMLURL         string    `json:"html_url"`
+	Homepage        string    `json:"homepage"`
+	Language        string    `json:"language"`
+	Topics          []string  `json:"topics"`
+	StargazersCount int       `json:"stargazers_count"`
+	ForksCount      int       `json:"forks_count"`
+	Fork            bool      `json:"fork"`
+	Archived        bool      `json:"archived"`
+	PushedAt        time.Time `json:"pushed_at"`
+}
+
+// ListUserRepositories возвращает публичные репозитории, которыми владеет пользователь,
+// начиная с недавно обновленных (не более maxRepositoryPages страниц)
+func (c *HTTPClient) ListUserRepositories(ctx context.Context, username string) ([]RepositorySummary, error) {
+	if !ValidUsername(username) {
+		return nil, ErrInvalidUsername
+	}
+
+	repositories := []RepositorySummary{}
+	for page := 1; page <= maxRepositoryPages; page++ {
+		query := url.Values{
+			"type":     {"owner"},
+			"sort":     {"pushed"},
+			"per_page": {strconv.Itoa(repositoriesPerPage)},
+			"page":     {strconv.Itoa(page)},
+		}
+
+		var response []repositorySummaryResponse
+		err := c.get(ctx, "/users/"+url.PathEscape(username)+"/repos?"+query.Encode(), &response)
+		if errors.Is(err, ErrRepositoryNotFound) {
+			return nil, ErrUserNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range response {
+			repository := RepositorySummary{
+				Name:        item.Name,
+				FullName:    item.FullName,
+				Description: item.Description,
+				URL:         item.HTMLURL,
+				Homepage:    item.Homepage,
+				Language:    item.Language,
+				Topics:      item.Topics,
+				Stars:       item.StargazersCount,
+				Forks:       item.ForksCount,
+				Fork:        item.Fork,
+				Archived:    item.Archived,
+				PushedAt:    item.PushedAt,
+			}
+			if repository.Topics == nil {
+				repository.Topics = []string{}
+			}
+			repositories = append(repositories, repository)
+		}
+
+		if len(response) < repositoriesPerPage {
+			break
+		}
+	}
+
+	return repositories, nil
+}
+
+// get выполняет GET-запрос к API и декодирует JSON-ответ в result
+func (c *HTTPClient) get(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	req.Header.Set("User-Agent", "dev-profile-platform")
+	if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := c.checkRateLimit(resp); err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrRepositoryNotFound
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("github: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// checkRateLimit распознает ответы об ограничении частоты запросов: основной лимит
+// (X-RateLimit-Remaining: 0) и вторичный (Retry-After)
+func (c *HTTPClient) checkRateLimit(resp *http.Response) error {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return &RateLimitError{ResetAt: c.now().Add(time.Duration(seconds) * time.Second)}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return &RateLimitError{ResetAt: time.Unix(reset, 0)}
+		}
+		return &RateLimitError{ResetAt: c.now().Add(defaultRetryAfter)}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{ResetAt: c.now().Add(defaultRetryAfter)}
+	}
+	return nil
+}
+
+// sortLanguages возвращает языки в порядке убывания объема кода
+func sortLanguages(languageBytes map[string]int) []string {
+	languages := make([]string, 0, len(languageBytes))
+	for language := range languageBytes {
+		languages = append(languages, language)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if languageBytes[languages[i]] != languageBytes[languages[j]] {
+			return languageBytes[languages[i]] > languageBytes[languages[j]]
+		}
+		return languages[i] < languages[j]
+	})
+	return languages
+}
@@ -0,0 +1,275 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fixedNow момент времени, от которого тесты отсчитывают ограничения частоты
+var fixedNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestClient запускает фейковый GitHub API и возвращает клиент, настроенный на него
+func newTestClient(t *testing.T, handler http.HandlerFunc) *HTTPClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewHTTPClient(HTTPConfig{BaseURL: server.URL + "/", Token: "secret"}, server.Client())
+	client.now = func() time.Time { return fixedNow }
+	return client
+}
+
+func TestGetRepository(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got := r.Header.Get("X-GitHub-Api-Version"); got != apiVersion {
+			t.Errorf("X-GitHub-Api-Version = %q", got)
+		}
+
+		switch r.URL.Path {
+		case "/repos/octo/app":
+			fmt.Fprint(w, `{
+				"full_name": "octo/app",
+				"stargazers_count": 42,
+				"forks_count": 7,
+				"language": "Go",
+				"topics": ["cli", "tools"],
+				"pushed_at": "2024-04-30T10:00:00Z",
+				"license": {"spdx_id": "MIT", "name": "MIT License"}
+			}`)
+		case "/repos/octo/app/languages":
+			fmt.Fprint(w, `{"Shell": 300, "Go": 5000, "Makefile": 300, "Dockerfile": 100}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	repo, err := client.GetRepository(context.Background(), "octo", "app")
+	if err != nil {
+		t.Fatalf("GetRepository: %v", err)
+	}
+
+	want := Repository{
+		FullName: "octo/app",
+		Stars:    42,
+		Forks:    7,
+		// По убыванию объема, при равенстве - по алфавиту
+		Languages: []string{"Go", "Makefile", "Shell", "Dockerfile"},
+		Topics:    []string{"cli", "tools"},
+		License:   "MIT",
+		PushedAt:  time.Date(2024, 4, 30, 10, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(repo, want) {
+		t.Fatalf("repo = %+v, want %+v", repo, want)
+	}
+}
+
+func TestGetRepositoryFallbacks(t *testing.T) {
+	tests := []struct {
+		name        string
+		repository  string
+		wantLicense string
+		wantLangs   []string
+	}{
+		{
+			name:        "license without spdx id",
+			repository:  `{"full_name": "octo/app", "language": "Rust", "license": {"spdx_id": "NOASSERTION", "name": "Custom License"}}`,
+			wantLicense: "Custom License",
+			wantLangs:   []string{"Rust"},
+		},
+		{
+			name:        "empty spdx id",
+			repository:  `{"full_name": "octo/app", "license": {"spdx_id": "", "name": "Other"}}`,
+			wantLicense: "Other",
+			wantLangs:   []string{},
+		},
+		{
+			name:        "no license",
+			repository:  `{"full_name": "octo/app", "license": null}`,
+			wantLicense: "",
+			wantLangs:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/repos/octo/app/languages" {
+					fmt.Fprint(w, `{}`)
+					return
+				}
+				fmt.Fprint(w, tt.repository)
+			})
+
+			repo, err := client.GetRepository(context.Background(), "octo", "app")
+			if err != nil {
+				t.Fatalf("GetRepository: %v", err)
+			}
+			if repo.License != tt.wantLicense {
+				t.Errorf("License = %q, want %q", repo.License, tt.wantLicense)
+			}
+			if !reflect.DeepEqual(repo.Languages, tt.wantLangs) {
+				t.Errorf("Languages = %v, want %v", repo.Languages, tt.wantLangs)
+			}
+			if repo.Topics == nil {
+				t.Error("Topics is nil")
+			}
+		})
+	}
+}
+
+func TestGetRepositoryNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	_, err := client.GetRepository(context.Background(), "octo", "missing")
+	if !errors.Is(err, ErrRepositoryNotFound) {
+		t.Fatalf("err = %v, want ErrRepositoryNotFound", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		headers   map[string]string
+		wantReset time.Time
+	}{
+		{
+			name:   "primary limit",
+			status: http.StatusForbidden,
+			headers: map[string]string{
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "1714568400",
+			},
+			wantReset: time.Unix(1714568400, 0),
+		},
+		{
+			name:      "primary limit without reset",
+			status:    http.StatusForbidden,
+			headers:   map[string]string{"X-RateLimit-Remaining": "0"},
+			wantReset: fixedNow.Add(defaultRetryAfter),
+		},
+		{
+			name:      "secondary limit with retry-after",
+			status:    http.StatusForbidden,
+			headers:   map[string]string{"Retry-After": "30", "X-RateLimit-Remaining": "10"},
+			wantReset: fixedNow.Add(30 * time.Second),
+		},
+		{
+			name:      "too many requests",
+			status:    http.StatusTooManyRequests,
+			wantReset: fixedNow.Add(defaultRetryAfter),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"message": "API rate limit exceeded"}`)
+			})
+
+			_, err := client.GetRepository(context.Background(), "octo", "app")
+			var rateLimit *RateLimitError
+			if !errors.As(err, &rateLimit) {
+				t.Fatalf("err = %v, want RateLimitError", err)
+			}
+			if !rateLimit.ResetAt.Equal(tt.wantReset) {
+				t.Fatalf("ResetAt = %v, want %v", rateLimit.ResetAt, tt.wantReset)
+			}
+		})
+	}
+}
+
+func TestForbiddenWithoutRateLimit(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "Repository access blocked"}`)
+	})
+
+	_, err := client.GetRepository(context.Background(), "octo", "app")
+	var rateLimit *RateLimitError
+	if err == nil || errors.As(err, &rateLimit) {
+		t.Fatalf("err = %v, want a plain error", err)
+	}
+}
+
+func TestListUserRepositories(t *testing.T) {
+	var pages []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/octo/repos" {
+			http.NotFound(w, r)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("type") != "owner" || query.Get("per_page") != "100" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		pages = append(pages, query.Get("page"))
+
+		if query.Get("page") == "1" {
+			fmt.Fprint(w, "[")
+			for i := 0; i < repositoriesPerPage; i++ {
+				if i > 0 {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprintf(w, `{"name": "repo%d", "html_url": "https://github.com/octo/repo%d"}`, i, i)
+			}
+			fmt.Fprint(w, "]")
+			return
+		}
+		fmt.Fprint(w, `[{"name": "last", "html_url": "https://github.com/octo/last", "language": "Go", "topics": ["api"], "fork": true}]`)
+	})
+
+	repositories, err := client.ListUserRepositories(context.Background(), "octo")
+	if err != nil {
+		t.Fatalf("ListUserRepositories: %v", err)
+	}
+	if !reflect.DeepEqual(pages, []string{"1", "2"}) {
+		t.Fatalf("pages = %v", pages)
+	}
+	if len(repositories) != repositoriesPerPage+1 {
+		t.Fatalf("got %d repositories", len(repositories))
+	}
+	last := repositories[len(repositories)-1]
+	if last.Name != "last" || last.Language != "Go" || !last.Fork || !reflect.DeepEqual(last.Topics, []string{"api"}) {
+		t.Fatalf("last = %+v", last)
+	}
+	if repositories[0].Topics == nil {
+		t.Fatal("Topics is nil")
+	}
+}
+
+func TestListUserRepositoriesErrors(t *testing.T) {
+	requested := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		http.NotFound(w, r)
+	})
+
+	if _, err := client.ListUserRepositories(context.Background(), "ghost"); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("err = %v, want ErrUserNotFound", err)
+	}
+
+	requested = false
+	for _, username := range []string{"", "../octo", "-octo", "octo/app"} {
+		if _, err := client.ListUserRepositories(context.Background(), username); !errors.Is(err, ErrInvalidUsername) {
+			t.Errorf("%q: err = %v, want ErrInvalidUsername", username, err)
+		}
+	}
+	if requested {
+		t.Fatal("invalid username reached the API")
+	}
+}
@@ -0,0 +1,206 @@
+// Package linkcheck проверяет доступность внешних ссылок с учетом robots.txt
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultUserAgent значение User-Agent запросов и имя робота для правил robots.txt
+	DefaultUserAgent = "DevProfileLinkChecker/1.0"
+	// DefaultTimeout время ожидания одной проверки
+	DefaultTimeout = 10 * time.Second
+	// DefaultRobotsTTL время хранения правил robots.txt сайта
+	DefaultRobotsTTL = 24 * time.Hour
+	// maxBodyBytes объем тела ответа, который читается при проверке через GET
+	maxBodyBytes = 64 << 10
+)
+
+// ErrUnsupportedURL возвращается для ссылок, которые не являются абсолютными http(s) адресами
+var ErrUnsupportedURL = errors.New("link must be an absolute http or https url")
+
+// Config описывает параметры проверки ссылок
+type Config struct {
+	// UserAgent по умолчанию DefaultUserAgent
+	UserAgent string
+	// Timeout по умолчанию DefaultTimeout
+	Timeout time.Duration
+	// RobotsTTL по умолчанию DefaultRobotsTTL
+	RobotsTTL time.Duration
+}
+
+// Result представляет результат проверки ссылки
+type Result struct {
+	// StatusCode код ответа; 0, если ответ не получен
+	StatusCode int
+	// OK ссылка доступна (код 2xx или 3xx после переходов)
+	OK bool
+	// Skipped проверка не выполнялась: ее запрещает robots.txt или сайт ограничил частоту запросов
+	Skipped bool
+	// Error описание ошибки или причины пропуска
+	Error string
+	// Duration время проверки
+	Duration time.Duration
+}
+
+// Checker проверяет ссылки запросами HEAD, а если сайт не поддерживает HEAD - запросами GET
+type Checker struct {
+	config Config
+	client *http.Client
+	now    func() time.Time
+
+	mu sync.Mutex
+	// robots правила robots.txt по схеме и хосту сайта
+	robots map[string]robotsEntry
+}
+
+// robotsEntry правила robots.txt сайта и время их получения
+type robotsEntry struct {
+	rules     robotsRules
+	fetchedAt time.Time
+}
+
+// NewChecker создает проверку ссылок на основе client (если client равен nil - http.DefaultClient).
+// Запросы к локальным и внутренним адресам, в том числе после редиректов, не выполняются.
+func NewChecker(config Config, client *http.Client) *Checker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	checker := newChecker(config, client)
+	checker.client = guardClient(client, checker.config.Timeout)
+	return checker
+}
+
+// newChecker создает проверку ссылок без ограничения адресов
+func newChecker(config Config, client *http.Client) *Checker {
+	if config.UserAgent == "" {
+		config.UserAgent = DefaultUserAgent
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.RobotsTTL <= 0 {
+		config.RobotsTTL = DefaultRobotsTTL
+	}
+	return &Checker{
+		config: config,
+		client: client,
+		now:    time.Now,
+		robots: make(map[string]robotsEntry),
+	}
+}
+
+// Check проверяет доступность ссылки
+func (c *Checker) Check(ctx context.Context, rawURL string) Result {
+	started := c.now()
+	result := c.check(ctx, rawURL)
+	result.Duration = c.now().Sub(started)
+	return result
+}
+
+// check выполняет проверку ссылки без учета времени
+func (c *Checker) check(ctx context.Context, rawURL string) Result {
+	target, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return Result{Error: ErrUnsupportedURL.Error()}
+	}
+
+	if !c.allowedByRobots(ctx, target) {
+		return Result{Skipped: true, Error: "disallowed by robots.txt"}
+	}
+
+	status, err := c.request(ctx, http.MethodHead, target.String())
+	if err == nil && status >= 400 {
+		// Часть сайтов отвечает на HEAD ошибкой (405, 403, 404), хотя страница доступна
+		status, err = c.request(ctx, http.MethodGet, target.String())
+	}
+	if errors.Is(err, ErrForbiddenAddress) {
+		// Текст исходной ошибки не возвращается, чтобы не раскрывать устройство внутренней сети
+		return Result{Error: ErrForbiddenAddress.Error()}
+	}
+	if err != nil {
+		return Result{Error: err.Error()}
+	}
+
+	result := Result{StatusCode: status, OK: status < 400}
+	switch {
+	case status == http.StatusTooManyRequests:
+		result.Skipped = true
+		result.Error = "rate limited by the site"
+	case !result.OK:
+		result.Error = fmt.Sprintf("unexpected status %d", status)
+	}
+	return result
+}
+
+// request выполняет запрос и возвращает код ответа
+func (c *Checker) request(ctx context.Context, method, target string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Дочитываем начало тела, чтобы соединение могло быть переиспользовано
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodyBytes))
+	return resp.StatusCode, nil
+}
+
+// allowedByRobots проверяет, разрешает ли robots.txt сайта обращаться к ссылке
+func (c *Checker) allowedByRobots(ctx context.Context, target *url.URL) bool {
+	site := target.Scheme + "://" + target.Host
+
+	c.mu.Lock()
+	entry, ok := c.robots[site]
+	c.mu.Unlock()
+
+	if !ok || c.now().Sub(entry.fetchedAt) > c.config.RobotsTTL {
+		entry = robotsEntry{rules: c.fetchRobots(ctx, site), fetchedAt: c.now()}
+		c.mu.Lock()
+		c.robots[site] = entry
+		c.mu.Unlock()
+	}
+
+	return entry.rules.allowed(target.EscapedPath())
+}
+
+// fetchRobots получает правила robots.txt сайта. Если файла нет или его не удалось получить,
+// ограничений нет: недоступность самого сайта покажет проверка ссылки.
+func (c *Checker) fetchRobots(ctx context.Context, site string) robotsRules {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, site+"/robots.txt", nil)
+	if err != nil {
+		return robotsRules{}
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return robotsRules{}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return robotsRules{}
+	}
+	return parseRobots(io.LimitReader(resp.Body, maxBodyBytes*8), c.config.UserAgent)
+}
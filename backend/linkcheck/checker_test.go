@@ -0,0 +1,246 @@
+package linkcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// requestLog записывает запросы, полученные тестовым сервером
+type requestLog struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (l *requestLog) add(r *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.requests = append(l.requests, r.Method+" "+r.URL.Path)
+}
+
+func (l *requestLog) list() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.requests...)
+}
+
+// newTestServer запускает сайт с заданным robots.txt; остальные запросы обрабатывает handler
+func newTestServer(t *testing.T, robots string, handler http.HandlerFunc) (*httptest.Server, *requestLog) {
+	t.Helper()
+	log := &requestLog{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.add(r)
+		if r.URL.Path == "/robots.txt" {
+			if robots == "" {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprint(w, robots)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, log
+}
+
+func TestCheckHeadOK(t *testing.T) {
+	server, log := newTestServer(t, "", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	result := newChecker(Config{}, server.Client()).Check(context.Background(), server.URL+"/page")
+	if !result.OK || result.StatusCode != http.StatusOK || result.Skipped || result.Error != "" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	want := []string{"GET /robots.txt", "HEAD /page"}
+	if got := log.list(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("requests = %v, want %v", got, want)
+	}
+}
+
+func TestCheckFallsBackToGet(t *testing.T) {
+	server, log := newTestServer(t, "", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		fmt.Fprint(w, "hello")
+	})
+
+	result := newChecker(Config{}, server.Client()).Check(context.Background(), server.URL+"/page")
+	if !result.OK || result.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	want := []string{"GET /robots.txt", "HEAD /page", "GET /page"}
+	if got := log.list(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("requests = %v, want %v", got, want)
+	}
+}
+
+func TestCheckBrokenLink(t *testing.T) {
+	server, _ := newTestServer(t, "", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	result := newChecker(Config{}, server.Client()).Check(context.Background(), server.URL+"/missing")
+	if result.OK || result.Skipped || result.StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if result.Error != "unexpected status 404" {
+		t.Fatalf("error = %q", result.Error)
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server, _ := newTestServer(t, "", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	defer close(release)
+
+	checker := newChecker(Config{Timeout: 50 * time.Millisecond}, server.Client())
+	result := checker.Check(context.Background(), server.URL+"/slow")
+	if result.OK || result.StatusCode != 0 || result.Error == "" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if !strings.Contains(result.Error, "deadline exceeded") {
+		t.Fatalf("error = %q, want a timeout", result.Error)
+	}
+}
+
+func TestCheckRateLimited(t *testing.T) {
+	server, _ := newTestServer(t, "", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	result := newChecker(Config{}, server.Client()).Check(context.Background(), server.URL+"/page")
+	if !result.Skipped || result.OK || result.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
+
+func TestCheckRespectsRobots(t *testing.T) {
+	robots := strings.Join([]string{
+		"User-agent: *",
+		"Disallow: /",
+		"",
+		"User-agent: DevProfileLinkChecker",
+		"Disallow: /private",
+		"Allow: /private/public",
+	}, "\n")
+	server, log := newTestServer(t, robots, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	checker := newChecker(Config{}, server.Client())
+
+	tests := []struct {
+		path    string
+		skipped bool
+	}{
+		{"/", false},
+		{"/private/page", true},
+		{"/private/public/page", false},
+	}
+	for _, tt := range tests {
+		result := checker.Check(context.Background(), server.URL+tt.path)
+		if result.Skipped != tt.skipped || result.OK == tt.skipped {
+			t.Errorf("%s: unexpected result %+v", tt.path, result)
+		}
+	}
+
+	// robots.txt запрашивается один раз, запрещенная страница не запрашивается
+	want := []string{"GET /robots.txt", "HEAD /", "HEAD /private/public/page"}
+	if got := log.list(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("requests = %v, want %v", got, want)
+	}
+}
+
+func TestCheckRejectsUnsupportedURL(t *testing.T) {
+	checker := newChecker(Config{}, nil)
+	for _, raw := range []string{"", "ftp://example.com/file", "/relative", "javascript:alert(1)"} {
+		result := checker.Check(context.Background(), raw)
+		if result.OK || result.Error != ErrUnsupportedURL.Error() {
+			t.Errorf("%q: unexpected result %+v", raw, result)
+		}
+	}
+}
+
+func TestNewCheckerBlocksPrivateAddresses(t *testing.T) {
+	server, log := newTestServer(t, "", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	result := NewChecker(Config{}, nil).Check(context.Background(), server.URL+"/page")
+	if result.OK || result.StatusCode != 0 || result.Error != ErrForbiddenAddress.Error() {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if got := log.list(); len(got) != 0 {
+		t.Fatalf("server received requests %v", got)
+	}
+}
+
+func TestCheckRedirect(t *testing.T) {
+	redirect := func(target string) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		return req
+	}
+
+	if err := checkRedirect(redirect("https://example.com/next"), make([]*http.Request, 1)); err != nil {
+		t.Fatalf("https redirect rejected: %v", err)
+	}
+	if err := checkRedirect(redirect("ftp://example.com/file"), make([]*http.Request, 1)); err == nil {
+		t.Fatal("ftp redirect allowed")
+	}
+	if err := checkRedirect(redirect("https://example.com/next"), make([]*http.Request, maxRedirects)); err == nil {
+		t.Fatal("redirect loop allowed")
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip     string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a00:1", false},
+	}
+	for _, tt := range tests {
+		if got := isPublicIP(parseIP(t, tt.ip)); got != tt.public {
+			t.Errorf("isPublicIP(%s) = %v, want %v", tt.ip, got, tt.public)
+		}
+	}
+}
+
+// parseIP разбирает адрес для тестов
+func parseIP(t *testing.T, raw string) net.IP {
+	t.Helper()
+	ip := net.ParseIP(raw)
+	if ip == nil {
+		t.Fatalf("invalid ip %q", raw)
+	}
+	return ip
+}
@@ -0,0 +1,98 @@
+package linkcheck
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// maxRedirects максимальное количество переходов по редиректам при проверке ссылки
+const maxRedirects = 10
+
+// ErrForbiddenAddress возвращается для ссылок, ведущих в локальную или внутреннюю сеть
+var ErrForbiddenAddress = errors.New("link points to a private or local network address")
+
+// reservedNetworks диапазоны, не покрытые методами net.IP, но недоступные из интернета
+var reservedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",     // "Эта" сеть
+	"100.64.0.0/10", // Carrier-grade NAT
+	"192.0.0.0/24",  // Служебные адреса IETF
+	"198.18.0.0/15", // Тестирование производительности сетей
+	"240.0.0.0/4",   // Зарезервировано
+	"64:ff9b::/96",  // NAT64: может вести на внутренние IPv4-адреса
+	"64:ff9b:1::/48",
+)
+
+// guardClient возвращает копию client, которая соединяется только с публичными адресами.
+// Адрес проверяется в момент соединения, поэтому проверка покрывает каждый редирект
+// и не обходится DNS-записями, указывающими на внутренние адреса.
+func guardClient(client *http.Client, timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		},
+	}
+
+	guarded := *client
+	guarded.Transport = &http.Transport{
+		// Прокси из окружения не используется: иначе проверялся бы адрес прокси, а не сайта
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+	}
+	guarded.CheckRedirect = checkRedirect
+	return &guarded
+}
+
+// checkRedirect разрешает переходы только по http(s) ссылкам и не более maxRedirects раз
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return errors.New("too many redirects")
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return ErrUnsupportedURL
+	}
+	return nil
+}
+
+// isPublicIP сообщает, является ли адрес публичным адресом интернета
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// mustParseCIDRs разбирает список сетей в нотации CIDR
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,123 @@
+package linkcheck
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// robotsRules правила robots.txt, относящиеся к проверке ссылок
+type robotsRules struct {
+	rules []robotsRule
+}
+
+// robotsRule правило Allow или Disallow
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// parseRobots разбирает robots.txt и оставляет правила группы, относящейся к userAgent,
+// а если такой группы нет - правила группы "*"
+func parseRobots(r io.Reader, userAgent string) robotsRules {
+	// Имя робота - часть User-Agent до "/", сравнивается без учета регистра
+	agent := strings.ToLower(strings.SplitN(userAgent, "/", 2)[0])
+
+	var specific, wildcard []robotsRule
+	var hasSpecific bool
+	var groupAgents []string
+	inRules := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// Строки User-agent после правил начинают новую группу
+			if inRules {
+				groupAgents = nil
+				inRules = false
+			}
+			groupAgents = append(groupAgents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			// Пустой Disallow разрешает все
+			if value == "" {
+				continue
+			}
+			rule := robotsRule{allow: key == "allow", pattern: value}
+			for _, groupAgent := range groupAgents {
+				switch {
+				case groupAgent == "*":
+					wildcard = append(wildcard, rule)
+				case groupAgent != "" && strings.Contains(agent, groupAgent):
+					specific = append(specific, rule)
+					hasSpecific = true
+				}
+			}
+		}
+	}
+
+	if hasSpecific {
+		return robotsRules{rules: specific}
+	}
+	return robotsRules{rules: wildcard}
+}
+
+// allowed проверяет путь по правилам: применяется правило с самым длинным шаблоном,
+// при равной длине Allow важнее Disallow
+func (r robotsRules) allowed(path string) bool {
+	if path == "" {
+		path = "/"
+	}
+
+	allowed := true
+	longest := -1
+	for _, rule := range r.rules {
+		if !matchRobotsPattern(rule.pattern, path) {
+			continue
+		}
+		if len(rule.pattern) > longest || (len(rule.pattern) == longest && rule.allow) {
+			longest = len(rule.pattern)
+			allowed = rule.allow
+		}
+	}
+	return allowed
+}
+
+// matchRobotsPattern сопоставляет путь с шаблоном robots.txt: шаблон совпадает с началом пути,
+// "*" означает любую последовательность символов, "$" в конце - конец пути
+func matchRobotsPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for _, part := range parts[1:] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+
+	if !anchored {
+		return true
+	}
+	// Для "$" последняя часть должна совпасть с концом пути
+	last := parts[len(parts)-1]
+	return rest == "" || (len(parts) > 1 && strings.HasSuffix(path, last))
+}
@@ -0,0 +1,105 @@
+package linkcheck
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRobotsSelectsGroup(t *testing.T) {
+	robots := strings.Join([]string{
+		"# comment",
+		"User-agent: *",
+		"Disallow: /everyone",
+		"",
+		"User-agent: OtherBot",
+		"User-agent: devprofilelinkchecker",
+		"Disallow: /ours # trailing comment",
+		"Allow: /ours/open",
+		"",
+		"User-agent: AnotherBot",
+		"Disallow: /",
+	}, "\n")
+
+	rules := parseRobots(strings.NewReader(robots), DefaultUserAgent)
+	want := []robotsRule{
+		{allow: false, pattern: "/ours"},
+		{allow: true, pattern: "/ours/open"},
+	}
+	if len(rules.rules) != len(want) {
+		t.Fatalf("rules = %+v, want %+v", rules.rules, want)
+	}
+	for i := range want {
+		if rules.rules[i] != want[i] {
+			t.Fatalf("rules = %+v, want %+v", rules.rules, want)
+		}
+	}
+}
+
+func TestParseRobotsFallsBackToWildcard(t *testing.T) {
+	robots := "User-agent: OtherBot\nDisallow: /\n\nUser-agent: *\nDisallow: /admin\nDisallow:\n"
+
+	rules := parseRobots(strings.NewReader(robots), DefaultUserAgent)
+	if len(rules.rules) != 1 || rules.rules[0] != (robotsRule{pattern: "/admin"}) {
+		t.Fatalf("rules = %+v", rules.rules)
+	}
+	if rules.allowed("/admin/users") || !rules.allowed("/") {
+		t.Fatal("wildcard rules not applied")
+	}
+}
+
+func TestRobotsAllowed(t *testing.T) {
+	rules := robotsRules{rules: []robotsRule{
+		{allow: false, pattern: "/docs"},
+		{allow: true, pattern: "/docs/public"},
+		{allow: false, pattern: "/*.pdf$"},
+		{allow: true, pattern: "/same"},
+		{allow: false, pattern: "/same"},
+	}}
+
+	tests := []struct {
+		path    string
+		allowed bool
+	}{
+		{"", true},
+		{"/", true},
+		{"/docs", false},
+		{"/docs/private", false},
+		{"/docs/public/page", true},
+		{"/files/report.pdf", false},
+		{"/files/report.pdf?download=1", true},
+		{"/same", true},
+	}
+	for _, tt := range tests {
+		if got := rules.allowed(tt.path); got != tt.allowed {
+			t.Errorf("allowed(%q) = %v, want %v", tt.path, got, tt.allowed)
+		}
+	}
+}
+
+func TestMatchRobotsPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"/", "/anything", true},
+		{"/fish", "/fish", true},
+		{"/fish", "/fish.html", true},
+		{"/fish", "/Fish", false},
+		{"/fish/", "/fish", false},
+		{"/*.php", "/index.php", true},
+		{"/*.php", "/folder/file.php?a=1", true},
+		{"/*.php", "/index.html", false},
+		{"/*.php$", "/index.php", true},
+		{"/*.php$", "/index.php?a=1", false},
+		{"/fish*shop", "/fish-and-shop", true},
+		{"/fish*shop", "/fish-and-sho", false},
+		{"/end$", "/end", true},
+		{"/end$", "/endless", false},
+	}
+	for _, tt := range tests {
+		if got := matchRobotsPattern(tt.pattern, tt.path); got != tt.match {
+			t.Errorf("matchRobotsPattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.match)
+		}
+	}
+}
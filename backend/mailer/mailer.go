@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"context"
+	"log"
+)
+
+// Message представляет письмо
+type Message struct {
+	To      string
+	Subject string
+	Body    string // текст письма (text/plain)
+}
+
+// Mailer отправляет письма пользователям
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// LogMailer вместо отправки пишет письма в лог; используется при разработке
+type LogMailer struct{}
+
+// NewLogMailer создает почтовый сервис, пишущий письма в лог
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// Send пишет письмо в лог
+func (m *LogMailer) Send(ctx context.Context, message Message) error {
+	log.Printf("Mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
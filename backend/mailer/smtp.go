@@ -0,0 +1,70 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPConfig описывает подключение к SMTP-серверу
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer отправляет письма через SMTP-сервер
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+// NewSMTPMailer создает почтовый сервис, работающий через SMTP
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	if config.Port == "" {
+		config.Port = "587"
+	}
+	return &SMTPMailer{config}
+}
+
+// Send отправляет письмо
+func (m *SMTPMailer) Send(ctx context.Context, message Message) error {
+	if strings.ContainsAny(message.To, "\r\n") || strings.ContainsAny(message.Subject, "\r\n") {
+		return fmt.Errorf("invalid mail header")
+	}
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	headers := []string{
+		"From: " + m.config.From,
+		"To: " + message.To,
+		"Subject: " + mime.QEncoding.Encode("utf-8", message.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: 8bit",
+	}
+	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + strings.ReplaceAll(message.Body, "\n", "\r\n")
+
+	// net/smtp не поддерживает контекст, поэтому отправляем в отдельной горутине
+	done := make(chan error, 1)
+	go func() {
+		addr := net.JoinHostPort(m.config.Host, m.config.Port)
+		done <- smtp.SendMail(addr, auth, m.config.From, []string{message.To}, []byte(body))
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
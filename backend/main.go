@@ -5,19 +5,41 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 
 	"your-project/backend/controllers"
+	"your-project/backend/github"
+	"your-project/backend/linkcheck"
+	"your-project/backend/mailer"
 	"your-project/backend/repositories"
 	"your-project/backend/services"
+	"your-project/backend/storage"
 )
 
 const (
 	DatabaseName = "portfolio"
 	MongoURI     = "mongodb://localhost:27017"
+
+	// UploadDir каталог для загруженных файлов при локальном хранении
+	UploadDir = "./uploads"
+	// UploadURLPrefix путь, по которому раздаются локально сохраненные файлы
+	UploadURLPrefix = "/uploads"
+
+	// PublicAPIURL внешний адрес API по умолчанию, используемый в ссылках из писем и профилей
+	PublicAPIURL = "http://localhost:8080"
+
+	// GitHubRequestTimeout время ожидания ответа GitHub API
+	GitHubRequestTimeout = 15 * time.Second
+	// LinkCheckTimeout время ожидания ответа сайта при проверке ссылки
+	LinkCheckTimeout = 10 * time.Second
+
+	// AnalyticsSalt соль для обезличивания посетителей в статистике просмотров
+	AnalyticsSalt = "your-analytics-salt" // В реальном приложении следует использовать переменную окружения
 )
 
 func main() {
@@ -42,17 +64,104 @@ func main() {
 	userRepo := repositories.NewUserRepository(client, DatabaseName)
 	projectRepo := repositories.NewProjectRepository(client, DatabaseName)
 	reviewRepo := repositories.NewReviewRepository(client, DatabaseName)
+	analyticsRepo := repositories.NewAnalyticsRepository(client, DatabaseName)
+	starRepo := repositories.NewStarRepository(client, DatabaseName)
+	commentRepo := repositories.NewCommentRepository(client, DatabaseName)
+	categoryRepo := repositories.NewCategoryRepository(client, DatabaseName)
+	linkCheckRepo := repositories.NewLinkCheckRepository(client, DatabaseName)
+	revisionRepo := repositories.NewRevisionRepository(client, DatabaseName)
+	technologyRepo := repositories.NewTechnologyRepository(client, DatabaseName)
+	collectionRepo := repositories.NewCollectionRepository(client, DatabaseName)
+
+	// Create file storage
+	fileStorage := newFileStorage()
+
+	// Create mailer
+	mailService := newMailer()
 
 	// Create services
-	userService := services.NewUserService(userRepo)
-	projectService := services.NewProjectService(projectRepo, userRepo)
+	imageService := services.NewImageService(fileStorage)
+	userService := services.NewUserService(userRepo, imageService)
+	projectService := services.NewProjectService(projectRepo, userRepo, categoryRepo, revisionRepo, collectionRepo, starRepo, commentRepo, imageService)
 	reviewService := services.NewReviewService(reviewRepo, userRepo)
+	resumeService := services.NewResumeService(userRepo, projectRepo)
+	jsonResumeService := services.NewJSONResumeService(userRepo, projectRepo, projectService)
+	analyticsService := services.NewAnalyticsService(analyticsRepo, AnalyticsSalt)
+	starService := services.NewStarService(starRepo, projectRepo, userRepo)
+	commentService := services.NewCommentService(commentRepo, projectRepo, userRepo)
+	contributorService := services.NewContributorService(projectRepo, userRepo)
+	galleryService := services.NewGalleryService(projectRepo, imageService)
+	categoryService := services.NewCategoryService(categoryRepo, projectRepo)
+	trendingService := services.NewTrendingService(projectRepo, starRepo, commentRepo, analyticsRepo)
+	linkCheckService := services.NewLinkCheckService(linkCheckRepo, userRepo, projectRepo, linkcheck.NewChecker(linkcheck.Config{
+		Timeout: LinkCheckTimeout,
+	}, &http.Client{Timeout: LinkCheckTimeout}))
+	githubClient := github.NewHTTPClient(github.HTTPConfig{
+		Token: os.Getenv("GITHUB_TOKEN"),
+	}, &http.Client{Timeout: GitHubRequestTimeout})
+	githubSyncService := services.NewGitHubSyncService(projectRepo, githubClient)
+	githubImportService := services.NewGitHubImportService(projectRepo, projectService, githubClient)
+	emailChangeService := services.NewEmailChangeService(userRepo, mailService, publicAPIURL())
+	socialCardService := services.NewSocialCardService(userRepo, projectRepo, fileStorage)
+	technologyService := services.NewTechnologyService(technologyRepo, projectRepo, userRepo)
+	collectionService := services.NewCollectionService(collectionRepo, projectRepo, userRepo)
+	completenessConfig, err := services.LoadCompletenessConfig(os.Getenv)
+	if err != nil {
+		log.Fatal("Invalid profile completeness config:", err)
+	}
+	completenessService := services.NewCompletenessService(completenessConfig, projectRepo, reviewRepo)
 
 	// Create controllers
-	userController := controllers.NewUserController(userService)
-	projectController := controllers.NewProjectController(projectService)
+	userController := controllers.NewUserController(userService, completenessService, analyticsService, publicAPIURL())
+	projectController := controllers.NewProjectController(projectService, analyticsService)
 	reviewController := controllers.NewReviewController(reviewService)
-	searchController := controllers.NewSearchController(userService, projectService)
+	starController := controllers.NewStarController(starService)
+	commentController := controllers.NewCommentController(commentService)
+	contributorController := controllers.NewContributorController(contributorService, projectService)
+	galleryController := controllers.NewGalleryController(galleryService, projectService)
+	githubController := controllers.NewGitHubController(githubSyncService, githubImportService, projectService)
+	linkCheckController := controllers.NewLinkCheckController(linkCheckService)
+	trendingController := controllers.NewTrendingController(trendingService)
+	revisionController := controllers.NewRevisionController(projectService)
+	categoryController := controllers.NewCategoryController(categoryService, strings.Split(os.Getenv("ADMIN_USER_IDS"), ","))
+	emailController := controllers.NewEmailController(emailChangeService)
+	analyticsController := controllers.NewAnalyticsController(analyticsService, projectService)
+	resumeController := controllers.NewResumeController(resumeService, jsonResumeService)
+	searchController := controllers.NewSearchController(userService, projectService, completenessService)
+	markdownController := controllers.NewMarkdownController()
+	socialCardController := controllers.NewSocialCardController(socialCardService)
+	technologyController := controllers.NewTechnologyController(technologyService)
+	collectionController := controllers.NewCollectionController(collectionService)
+
+	// Render Markdown for documents saved before HTML was stored
+	go func() {
+		ctx := context.Background()
+		if n, err := projectService.RenderMissingDescriptions(ctx); err != nil {
+			log.Printf("Failed to render project descriptions: %v", err)
+		} else if n > 0 {
+			log.Printf("Rendered %d project descriptions", n)
+		}
+		if n, err := userService.RenderMissingBios(ctx); err != nil {
+			log.Printf("Failed to render user bios: %v", err)
+		} else if n > 0 {
+			log.Printf("Rendered %d user bios", n)
+		}
+	}()
+
+	// Publish scheduled drafts in the background
+	go projectService.RunScheduledPublishing(context.Background(), services.ScheduledPublishInterval)
+
+	// Refresh GitHub repository data in the background
+	go githubSyncService.Run(context.Background(), services.GitHubSyncInterval)
+
+	// Recalculate trending projects in the background
+	go trendingService.Run(context.Background(), services.TrendingInterval)
+
+	// Check profile and project links in the background
+	go linkCheckService.Run(context.Background(), services.LinkCheckInterval)
+
+	// Recount technologies for autocomplete in the background
+	go technologyService.Run(context.Background(), services.TechnologyRefreshInterval)
 
 	// Setup Gin
 	router := gin.Default()
@@ -67,13 +176,32 @@ func main() {
 		MaxAge:           12 * time.Hour,
 	}))
 
+	// Serve locally stored uploads
+	router.Static(UploadURLPrefix, UploadDir)
+
 	// Register routes
 	api := router.Group("/api")
 	{
 		userController.RegisterRoutes(api)
 		projectController.RegisterRoutes(api)
 		reviewController.RegisterRoutes(api)
+		resumeController.RegisterRoutes(api)
+		analyticsController.RegisterRoutes(api)
+		emailController.RegisterRoutes(api)
+		starController.RegisterRoutes(api)
+		commentController.RegisterRoutes(api)
+		contributorController.RegisterRoutes(api)
+		galleryController.RegisterRoutes(api)
+		githubController.RegisterRoutes(api)
+		categoryController.RegisterRoutes(api)
+		linkCheckController.RegisterRoutes(api)
+		trendingController.RegisterRoutes(api)
+		revisionController.RegisterRoutes(api)
 		searchController.RegisterRoutes(api)
+		markdownController.RegisterRoutes(api)
+		socialCardController.RegisterRoutes(api)
+		technologyController.RegisterRoutes(api)
+		collectionController.RegisterRoutes(api)
 	}
 
 	// Add a test route for health checking
@@ -90,3 +218,45 @@ func main() {
 		log.Fatal("Failed to start server:", err)
 	}
 }
+
+// publicAPIURL возвращает внешний адрес API: значение PUBLIC_API_URL или PublicAPIURL.
+// Адрес задается настройкой, а не берется из заголовков запроса, которые может подменить клиент.
+func publicAPIURL() string {
+	if url := os.Getenv("PUBLIC_API_URL"); url != "" {
+		return url
+	}
+	return PublicAPIURL
+}
+
+// newFileStorage выбирает хранилище файлов: S3-совместимое, если задан S3_ENDPOINT, иначе локальный каталог
+func newFileStorage() storage.Storage {
+	endpoint := os.Getenv("S3_ENDPOINT")
+	if endpoint == "" {
+		return storage.NewLocalStorage(UploadDir, UploadURLPrefix)
+	}
+
+	return storage.NewS3Storage(storage.S3Config{
+		Endpoint:  endpoint,
+		Region:    os.Getenv("S3_REGION"),
+		Bucket:    os.Getenv("S3_BUCKET"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+		PublicURL: os.Getenv("S3_PUBLIC_URL"),
+	}, &http.Client{Timeout: 30 * time.Second})
+}
+
+// newMailer выбирает почтовый сервис: SMTP, если задан SMTP_HOST, иначе вывод писем в лог
+func newMailer() mailer.Mailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return mailer.NewLogMailer()
+	}
+
+	return mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:     host,
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	})
+}
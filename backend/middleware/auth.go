@@ -107,3 +107,40 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware middleware, который сохраняет данные пользователя в контексте,
+// если передан валидный токен, но не отклоняет анонимные запросы
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if claims, err := ParseToken(parts[1]); err == nil {
+				c.Set("user_id", claims.UserID)
+				c.Set("email", claims.Email)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// AdminMiddleware middleware, который пропускает только администраторов из списка adminIDs.
+// Используется после AuthMiddleware.
+func AdminMiddleware(adminIDs []string) gin.HandlerFunc {
+	admins := make(map[string]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			admins[id] = true
+		}
+	}
+
+	return func(c *gin.Context) {
+		if !admins[c.GetString("user_id")] {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only administrators can do this"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,29 @@
+package models
+
+// Типы объектов, просмотры которых учитываются
+const (
+	ViewTargetUser    = "user"
+	ViewTargetProject = "project"
+)
+
+// ViewAnalytics представляет статистику просмотров профиля или проекта за период
+type ViewAnalytics struct {
+	From           string          `json:"from"`
+	To             string          `json:"to"`
+	TotalViews     int             `json:"totalViews"`
+	UniqueVisitors int             `json:"uniqueVisitors"`
+	Referrers      []ReferrerCount `json:"referrers"`
+	Series         []DailyViews    `json:"series"`
+}
+
+// DailyViews представляет количество просмотров за день
+type DailyViews struct {
+	Date  string `bson:"day" json:"date"`
+	Views int    `bson:"views" json:"views"`
+}
+
+// ReferrerCount представляет количество переходов с одного источника
+type ReferrerCount struct {
+	Referrer string `bson:"_id" json:"referrer"`
+	Count    int    `bson:"count" json:"count"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Category представляет категорию проектов, которой управляют администраторы
+type Category struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Slug        string             `bson:"slug" json:"slug"` // Постоянный идентификатор, хранится в Project.Category
+	Name        string             `bson:"name" json:"name"`
+	Description string             `bson:"description" json:"description"`
+	CreatedAt   time.Time          `bson:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updatedAt"`
+}
+
+// DefaultCategories категории, создаваемые при первом запуске
+var DefaultCategories = []Category{
+	{Slug: "web-app", Name: "Web app"},
+	{Slug: "cli", Name: "CLI"},
+	{Slug: "library", Name: "Library"},
+	{Slug: "mobile", Name: "Mobile"},
+	{Slug: "data", Name: "Data"},
+	{Slug: "game", Name: "Game"},
+	{Slug: "other", Name: "Other"},
+}
+
+// ProjectFilter описывает фильтры каталога проектов. Проект подходит, если относится к одной
+// из категорий и содержит все указанные теги и технологии.
+type ProjectFilter struct {
+	Categories   []string
+	Tags         []string
+	Technologies []string
+}
+
+// FacetCount представляет количество проектов с одним значением фасета
+type FacetCount struct {
+	Value string `bson:"_id" json:"value"`
+	Count int    `bson:"count" json:"count"`
+}
+
+// ProjectFacets представляет количество проектов по категориям, тегам и технологиям
+type ProjectFacets struct {
+	Categories   []FacetCount `bson:"categories" json:"categories"`
+	Tags         []FacetCount `bson:"tags" json:"tags"`
+	Technologies []FacetCount `bson:"technologies" json:"technologies"`
+}
+
+// ProjectBrowsePage представляет страницу каталога проектов вместе с фасетами
+type ProjectBrowsePage struct {
+	Projects []Project     `json:"projects"`
+	Total    int           `json:"total"`
+	Page     int           `json:"page"`
+	Limit    int           `json:"limit"`
+	Facets   ProjectFacets `json:"facets"`
+}
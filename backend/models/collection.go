@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Видимость подборки проектов
+const (
+	CollectionPublic  = "public"
+	CollectionPrivate = "private"
+)
+
+// Collection представляет именованную упорядоченную подборку проектов пользователя
+// (как своих, так и чужих)
+type Collection struct {
+	ID          primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	UserID      primitive.ObjectID   `bson:"user_id" json:"userId"`
+	UserName    string               `bson:"user_name" json:"userName"`
+	UserAvatar  string               `bson:"user_avatar" json:"userAvatar"`
+	Name        string               `bson:"name" json:"name"`
+	Description string               `bson:"description" json:"description"`
+	Visibility  string               `bson:"visibility" json:"visibility"`  // public или private
+	ProjectIDs  []primitive.ObjectID `bson:"project_ids" json:"projectIds"` // Проекты в порядке подборки
+	CreatedAt   time.Time            `bson:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time            `bson:"updated_at" json:"updatedAt"`
+}
+
+// IsVisibleTo сообщает, может ли пользователь видеть подборку: публичные видны всем, приватные - только автору
+func (c Collection) IsVisibleTo(userID string) bool {
+	return c.Visibility != CollectionPrivate || c.UserID.Hex() == userID
+}
+
+// CollectionPage представляет подборку вместе с проектами, доступными зрителю
+type CollectionPage struct {
+	Collection
+	Projects []Project `json:"projects"`
+}
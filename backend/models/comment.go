@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Comment представляет комментарий к проекту. Ответы возможны только на комментарии
+// верхнего уровня (ParentID пустой), поэтому обсуждение имеет один уровень вложенности.
+type Comment struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	ProjectID    primitive.ObjectID  `bson:"project_id" json:"projectId"`
+	ParentID     *primitive.ObjectID `bson:"parent_id" json:"parentId"`
+	AuthorID     primitive.ObjectID  `bson:"author_id" json:"authorId"`
+	AuthorName   string              `bson:"author_name" json:"authorName"`
+	AuthorAvatar string              `bson:"author_avatar" json:"authorAvatar"`
+	Content      string              `bson:"content" json:"content"`          // Исходный Markdown
+	ContentHTML  string              `bson:"content_html" json:"contentHtml"` // Безопасный HTML
+	ReplyCount   int                 `bson:"reply_count" json:"replyCount"`
+	CreatedAt    time.Time           `bson:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time           `bson:"updated_at" json:"updatedAt"`
+	EditedAt     *time.Time          `bson:"edited_at,omitempty" json:"editedAt,omitempty"`
+}
+
+// CommentPage представляет страницу комментариев при постраничной выдаче по курсору
+type CommentPage struct {
+	Comments   []Comment `json:"comments"`
+	NextCursor string    `json:"nextCursor,omitempty"` // Пустой, если это последняя страница
+}
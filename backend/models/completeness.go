@@ -0,0 +1,14 @@
+package models
+
+// ProfileCompleteness представляет оценку заполненности профиля пользователя
+type ProfileCompleteness struct {
+	Score   int                `json:"score"` // от 0 до 100
+	Missing []CompletenessItem `json:"missing"`
+}
+
+// CompletenessItem представляет незаполненный пункт профиля с подсказкой
+type CompletenessItem struct {
+	Key        string `json:"key"`
+	Weight     int    `json:"weight"`
+	Suggestion string `json:"suggestion"`
+}
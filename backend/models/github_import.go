@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GitHubImportCandidate представляет репозиторий GitHub, который можно импортировать как проект
+type GitHubImportCandidate struct {
+	Name        string    `json:"name"`
+	FullName    string    `json:"fullName"`
+	Description string    `json:"description"`
+	URL         string    `json:"url"`
+	Homepage    string    `json:"homepage"`
+	Language    string    `json:"language"`
+	Topics      []string  `json:"topics"`
+	Stars       int       `json:"stars"`
+	Forks       int       `json:"forks"`
+	Fork        bool      `json:"fork"`
+	Archived    bool      `json:"archived"`
+	PushedAt    time.Time `json:"pushedAt"`
+	// ProjectID проект пользователя с этим репозиторием, если репозиторий уже импортирован
+	ProjectID *primitive.ObjectID `json:"projectId,omitempty"`
+}
+
+// Причины, по которым репозиторий не был импортирован
+const (
+	ImportSkipDuplicate = "duplicate" // У пользователя уже есть проект с этим репозиторием
+	ImportSkipNotFound  = "not_found" // Репозитория нет среди публичных репозиториев пользователя GitHub
+	ImportSkipFailed    = "failed"    // Проект не удалось создать, причина - в Error
+)
+
+// GitHubImportSkip описывает репозиторий, пропущенный при импорте
+type GitHubImportSkip struct {
+	Name      string              `json:"name"`
+	Reason    string              `json:"reason"`
+	ProjectID *primitive.ObjectID `json:"projectId,omitempty"` // Существующий проект для ImportSkipDuplicate
+	Error     string              `json:"error,omitempty"`     // Причина для ImportSkipFailed
+}
+
+// GitHubImportResult представляет результат импорта репозиториев
+type GitHubImportResult struct {
+	Created []Project          `json:"created"`
+	Skipped []GitHubImportSkip `json:"skipped"`
+}
@@ -0,0 +1,77 @@
+package models
+
+// JSONResume представляет документ в формате JSON Resume (https://jsonresume.org/schema).
+// Поддерживаются только разделы, которые есть в профиле платформы.
+type JSONResume struct {
+	Schema   string              `json:"$schema,omitempty"`
+	Basics   JSONResumeBasics    `json:"basics"`
+	Skills   []JSONResumeSkill   `json:"skills,omitempty"`
+	Projects []JSONResumeProject `json:"projects,omitempty"`
+	Meta     *JSONResumeMeta     `json:"meta,omitempty"`
+}
+
+// JSONResumeBasics представляет раздел basics
+type JSONResumeBasics struct {
+	Name     string              `json:"name"`
+	Label    string              `json:"label,omitempty"`
+	Image    string              `json:"image,omitempty"`
+	Email    string              `json:"email,omitempty"`
+	URL      string              `json:"url,omitempty"`
+	Summary  string              `json:"summary,omitempty"`
+	Profiles []JSONResumeProfile `json:"profiles,omitempty"`
+}
+
+// JSONResumeProfile представляет ссылку на профиль в социальной сети
+type JSONResumeProfile struct {
+	Network  string `json:"network"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// JSONResumeSkill представляет навык
+type JSONResumeSkill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// JSONResumeProject представляет проект
+type JSONResumeProject struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Highlights  []string `json:"highlights,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	URL         string   `json:"url,omitempty"`
+}
+
+// JSONResumeMeta представляет служебные данные документа
+type JSONResumeMeta struct {
+	Canonical    string `json:"canonical,omitempty"`
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// ResumeImportResult описывает изменения, внесенные (или планируемые в режиме dry-run) импортом резюме
+type ResumeImportResult struct {
+	DryRun          bool            `json:"dryRun"`
+	ProfileChanges  []FieldChange   `json:"profileChanges"`
+	CreatedProjects []string        `json:"createdProjects"`
+	UpdatedProjects []ProjectChange `json:"updatedProjects"`
+	SkippedProjects []string        `json:"skippedProjects"` // повторы проектов, уже созданных этим импортом
+}
+
+// FieldChange описывает изменение одного поля
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// ProjectChange описывает изменения существующего проекта
+type ProjectChange struct {
+	ID      string        `json:"id"`
+	Title   string        `json:"title"`
+	Changes []FieldChange `json:"changes"`
+}
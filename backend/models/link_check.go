@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Статусы проверки ссылки
+const (
+	LinkStatusOK      = "ok"
+	LinkStatusBroken  = "broken"
+	LinkStatusSkipped = "skipped" // Проверку запретил robots.txt или сайт ограничил частоту запросов
+)
+
+// Поля, ссылки из которых проверяются
+const (
+	LinkFieldLiveURL   = "liveUrl"
+	LinkFieldGitHubURL = "githubUrl"
+	LinkFieldWebsite   = "social.website"
+	LinkFieldGitHub    = "social.github"
+	LinkFieldTwitter   = "social.twitter"
+	LinkFieldLinkedIn  = "social.linkedin"
+)
+
+// LinkCheckResult представляет результат одной проверки ссылки
+type LinkCheckResult struct {
+	Status     string    `bson:"status" json:"status"`
+	StatusCode int       `bson:"status_code" json:"statusCode"`
+	Error      string    `bson:"error,omitempty" json:"error,omitempty"`
+	DurationMs int64     `bson:"duration_ms" json:"durationMs"`
+	CheckedAt  time.Time `bson:"checked_at" json:"checkedAt"`
+}
+
+// LinkCheck представляет состояние ссылки из профиля или проекта пользователя
+type LinkCheck struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID       primitive.ObjectID  `bson:"user_id" json:"userId"`
+	ProjectID    *primitive.ObjectID `bson:"project_id" json:"projectId,omitempty"` // nil для ссылок профиля
+	ProjectTitle string              `bson:"project_title,omitempty" json:"projectTitle,omitempty"`
+	Field        string              `bson:"field" json:"field"`
+	URL          string              `bson:"url" json:"url"`
+	Status       string              `bson:"status" json:"status"`
+	StatusCode   int                 `bson:"status_code" json:"statusCode"`
+	Error        string              `bson:"error,omitempty" json:"error,omitempty"`
+	CheckedAt    time.Time           `bson:"checked_at" json:"checkedAt"`
+	BrokenSince  *time.Time          `bson:"broken_since,omitempty" json:"brokenSince,omitempty"`
+	History      []LinkCheckResult   `bson:"history" json:"history"` // Последние проверки, начиная со старых
+}
+
+// LinkReport представляет состояние всех ссылок пользователя
+type LinkReport struct {
+	Total  int         `json:"total"`
+	Broken int         `json:"broken"`
+	Links  []LinkCheck `json:"links"`
+}
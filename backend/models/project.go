@@ -1,4 +1,3 @@
-
 package models
 
 import (
@@ -9,16 +8,161 @@ import (
 
 // Project представляет модель проекта
 type Project struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Title        string             `bson:"title" json:"title"`
-	Description  string             `bson:"description" json:"description"`
-	Image        string             `bson:"image" json:"image"`
-	Technologies []string           `bson:"technologies" json:"technologies"`
-	GitHubURL    string             `bson:"github_url" json:"githubUrl"`
-	LiveURL      string             `bson:"live_url" json:"liveUrl"`
-	UserID       primitive.ObjectID `bson:"user_id" json:"userId"`
-	UserName     string             `bson:"user_name" json:"userName"`
-	UserAvatar   string             `bson:"user_avatar" json:"userAvatar"`
-	CreatedAt    time.Time          `bson:"created_at" json:"createdAt"`
-	UpdatedAt    time.Time          `bson:"updated_at" json:"updatedAt"`
+	ID              primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Title           string              `bson:"title" json:"title"`
+	Description     string              `bson:"description" json:"description"`          // Markdown
+	DescriptionHTML string              `bson:"description_html" json:"descriptionHtml"` // Безопасный HTML из Description
+	Image           string              `bson:"image" json:"image"`
+	ImageThumbnails map[string]string   `bson:"image_thumbnails,omitempty" json:"imageThumbnails,omitempty"` // Уменьшенные копии изображения
+	Technologies    []string            `bson:"technologies" json:"technologies"`
+	Category        string              `bson:"category" json:"category"`    // Slug категории
+	Tags            []string            `bson:"tags" json:"tags"`            // Произвольные теги в нижнем регистре
+	CaseStudy       []CaseStudySection  `bson:"case_study" json:"caseStudy"` // Разделы подробного описания в порядке CaseStudySections
+	GitHubURL       string              `bson:"github_url" json:"githubUrl"`
+	LiveURL         string              `bson:"live_url" json:"liveUrl"`
+	Status          string              `bson:"status,omitempty" json:"status"`                  // draft, published или archived
+	PublishAt       *time.Time          `bson:"publish_at,omitempty" json:"publishAt,omitempty"` // Плановая публикация черновика
+	UserID          primitive.ObjectID  `bson:"user_id" json:"userId"`
+	UserName        string              `bson:"user_name" json:"userName"`
+	UserAvatar      string              `bson:"user_avatar" json:"userAvatar"`
+	Contributors    []Contributor       `bson:"contributors,omitempty" json:"contributors"` // Участники помимо владельца (UserID)
+	CreatedAt       time.Time           `bson:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time           `bson:"updated_at" json:"updatedAt"`
+	Gallery         []MediaItem         `bson:"gallery,omitempty" json:"gallery"`
+	CoverMediaID    *primitive.ObjectID `bson:"cover_media_id,omitempty" json:"coverMediaId,omitempty"`
+	GitHub          *GitHubStats        `bson:"github,omitempty" json:"github,omitempty"`    // Данные репозитория по GitHubURL
+	StarCount       int                 `bson:"star_count,omitempty" json:"starCount"`       // Меняется только атомарно при отметках
+	CommentCount    int                 `bson:"comment_count,omitempty" json:"commentCount"` // Меняется только атомарно при комментировании
+	Pinned          bool                `bson:"-" json:"pinned,omitempty"`                   // Закреплен в профиле, для которого получен список
+}
+
+// GitHubStats представляет метаданные репозитория проекта, полученные из GitHub
+type GitHubStats struct {
+	FullName  string    `bson:"full_name" json:"fullName"`
+	Stars     int       `bson:"stars" json:"stars"`
+	Forks     int       `bson:"forks" json:"forks"`
+	Languages []string  `bson:"languages" json:"languages"`
+	Topics    []string  `bson:"topics" json:"topics"`
+	License   string    `bson:"license" json:"license"`
+	PushedAt  time.Time `bson:"pushed_at" json:"pushedAt"`
+	SyncedAt  time.Time `bson:"synced_at" json:"syncedAt"`
+	SyncError string    `bson:"sync_error,omitempty" json:"syncError,omitempty"` // Причина неудачной последней синхронизации
+}
+
+// Статусы публикации проекта
+const (
+	ProjectDraft     = "draft"
+	ProjectPublished = "published"
+	ProjectArchived  = "archived"
+)
+
+// IsPublished сообщает, опубликован ли проект (проекты без статуса считаются опубликованными)
+func (p Project) IsPublished() bool {
+	return p.Status == "" || p.Status == ProjectPublished
+}
+
+// IsVisibleTo сообщает, может ли пользователь видеть проект: опубликованные проекты видны всем,
+// черновики и архивные - только владельцу и участникам
+func (p Project) IsVisibleTo(userID string) bool {
+	return p.IsPublished() || p.RoleOf(userID) != ""
+}
+
+// Роли участников проекта
+const (
+	RoleOwner       = "owner"
+	RoleMaintainer  = "maintainer"
+	RoleContributor = "contributor"
+)
+
+// Статусы участия в проекте
+const (
+	ContributorInvited  = "invited"
+	ContributorAccepted = "accepted"
+)
+
+// Contributor представляет участника проекта и его роль
+type Contributor struct {
+	UserID     primitive.ObjectID `bson:"user_id" json:"userId"`
+	UserName   string             `bson:"user_name" json:"userName"`
+	UserAvatar string             `bson:"user_avatar" json:"userAvatar"`
+	Role       string             `bson:"role" json:"role"`
+	Status     string             `bson:"status" json:"status"`
+	InvitedBy  primitive.ObjectID `bson:"invited_by" json:"invitedBy"`
+	InvitedAt  time.Time          `bson:"invited_at" json:"invitedAt"`
+	AcceptedAt *time.Time         `bson:"accepted_at,omitempty" json:"acceptedAt,omitempty"`
+}
+
+// RoleOf возвращает роль пользователя в проекте или пустую строку,
+// если пользователь не владелец и не принявший приглашение участник
+func (p Project) RoleOf(userID string) string {
+	if userID == "" {
+		return ""
+	}
+	if p.UserID.Hex() == userID {
+		return RoleOwner
+	}
+	for _, contributor := range p.Contributors {
+		if contributor.UserID.Hex() == userID && contributor.Status == ContributorAccepted {
+			return contributor.Role
+		}
+	}
+	return ""
+}
+
+// CanEdit сообщает, может ли пользователь редактировать проект (владелец или мейнтейнер)
+func (p Project) CanEdit(userID string) bool {
+	role := p.RoleOf(userID)
+	return role == RoleOwner || role == RoleMaintainer
+}
+
+// Типы разделов подробного описания проекта (case study)
+const (
+	CaseStudyProblem      = "problem"
+	CaseStudyApproach     = "approach"
+	CaseStudyArchitecture = "architecture"
+	CaseStudyResults      = "results"
+	CaseStudyLessons      = "lessons"
+	CaseStudyRole         = "role"
+)
+
+// CaseStudySections типы разделов подробного описания в порядке их вывода
+var CaseStudySections = []string{
+	CaseStudyProblem,
+	CaseStudyApproach,
+	CaseStudyArchitecture,
+	CaseStudyResults,
+	CaseStudyLessons,
+	CaseStudyRole,
+}
+
+// CaseStudySection представляет раздел подробного описания проекта
+type CaseStudySection struct {
+	Type        string            `bson:"type" json:"type"`
+	Content     string            `bson:"content" json:"content"`                              // Markdown
+	ContentHTML string            `bson:"content_html,omitempty" json:"contentHtml,omitempty"` // Безопасный HTML из Content
+	Metrics     []CaseStudyMetric `bson:"metrics,omitempty" json:"metrics,omitempty"`          // Только для раздела results
+}
+
+// CaseStudyMetric представляет измеримый результат проекта, например "Время ответа" - "-40%"
+type CaseStudyMetric struct {
+	Label string `bson:"label" json:"label"`
+	Value string `bson:"value" json:"value"`
+}
+
+// Типы элементов галереи проекта
+const (
+	MediaTypeImage = "image"
+	MediaTypeVideo = "video"
+)
+
+// MediaItem представляет элемент галереи проекта (скриншот или ссылку на видео)
+type MediaItem struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	Type       string             `bson:"type" json:"type"`
+	URL        string             `bson:"url" json:"url"`
+	Thumbnails map[string]string  `bson:"thumbnails,omitempty" json:"thumbnails,omitempty"`
+	Caption    string             `bson:"caption" json:"caption"`
+	AltText    string             `bson:"alt_text" json:"altText"`
+	Order      int                `bson:"order" json:"order"`
+	CreatedAt  time.Time          `bson:"created_at" json:"createdAt"`
 }
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Действия, в результате которых создается версия проекта
+const (
+	RevisionInitial = "initial" // Состояние проекта до начала ведения истории
+	RevisionCreate  = "create"
+	RevisionUpdate  = "update"
+	RevisionRestore = "restore"
+	RevisionPublish = "publish" // Плановая публикация черновика, без автора
+)
+
+// ProjectSnapshot представляет редактируемое содержимое проекта в одной из версий.
+// Имена полей bson совпадают с полями Project.
+type ProjectSnapshot struct {
+	Title        string             `bson:"title" json:"title"`
+	Description  string             `bson:"description" json:"description"`
+	Technologies []string           `bson:"technologies" json:"technologies"`
+	Category     string             `bson:"category" json:"category"`
+	Tags         []string           `bson:"tags" json:"tags"`
+	CaseStudy    []CaseStudySection `bson:"case_study" json:"caseStudy"` // Без HTML, он формируется при восстановлении
+	GitHubURL    string             `bson:"github_url" json:"githubUrl"`
+	LiveURL      string             `bson:"live_url" json:"liveUrl"`
+	Status       string             `bson:"status" json:"status"`
+	PublishAt    *time.Time         `bson:"publish_at" json:"publishAt"`
+}
+
+// ProjectRevision представляет версию проекта: кто и когда изменил проект и какие поля изменились
+type ProjectRevision struct {
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	ProjectID     primitive.ObjectID  `bson:"project_id" json:"projectId"`
+	Number        int                 `bson:"number" json:"number"` // Номер версии в проекте, начиная с 1
+	Action        string              `bson:"action" json:"action"`
+	AuthorID      *primitive.ObjectID `bson:"author_id,omitempty" json:"authorId,omitempty"` // nil для начального состояния и плановой публикации
+	AuthorName    string              `bson:"author_name,omitempty" json:"authorName,omitempty"`
+	ChangedFields []string            `bson:"changed_fields" json:"changedFields"`
+	RestoredFrom  int                 `bson:"restored_from,omitempty" json:"restoredFrom,omitempty"` // Номер восстановленной версии
+	Snapshot      ProjectSnapshot     `bson:"snapshot" json:"snapshot"`
+	CreatedAt     time.Time           `bson:"created_at" json:"createdAt"`
+}
+
+// RevisionDiff представляет различия между двумя версиями проекта
+type RevisionDiff struct {
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Star представляет отметку проекта пользователем (не более одной на пользователя и проект)
+type Star struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ProjectID  primitive.ObjectID `bson:"project_id" json:"projectId"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"userId"`
+	UserName   string             `bson:"user_name" json:"userName"`
+	UserAvatar string             `bson:"user_avatar" json:"userAvatar"`
+	CreatedAt  time.Time          `bson:"created_at" json:"createdAt"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Technology представляет технологию для автодополнения: название из проектов и навыков
+// пользователей с количеством использований
+type Technology struct {
+	Key          string    `bson:"_id" json:"-"`     // Нормализованное название
+	Name         string    `bson:"name" json:"name"` // Самое частое написание
+	Terms        []string  `bson:"terms" json:"-"`   // Нормализованные написания, по которым ищется технология
+	ProjectCount int       `bson:"project_count" json:"projectCount"`
+	SkillCount   int       `bson:"skill_count" json:"skillCount"`
+	Count        int       `bson:"count" json:"count"` // ProjectCount + SkillCount
+	RefreshedAt  time.Time `bson:"refreshed_at" json:"-"`
+}
+
+// NameCount представляет количество использований одного написания названия
+type NameCount struct {
+	Name  string `bson:"_id"`
+	Count int    `bson:"count"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DailyActivity представляет количество событий (отметок, комментариев, просмотров) проекта за день
+type DailyActivity struct {
+	ProjectID primitive.ObjectID `bson:"project_id"`
+	Day       string             `bson:"day"` // YYYY-MM-DD в UTC
+	Count     int                `bson:"count"`
+}
+
+// TrendingProject представляет проект в рейтинге популярности вместе с его оценкой
+type TrendingProject struct {
+	Project
+	TrendingScore float64 `json:"trendingScore"`
+}
+
+// TrendingList представляет рейтинг популярных проектов, общий или по технологии
+type TrendingList struct {
+	GeneratedAt  time.Time         `json:"generatedAt"`
+	Technology   string            `json:"technology,omitempty"`
+	Projects     []TrendingProject `json:"projects"`
+	Technologies []string          `json:"technologies"` // Технологии, для которых есть рейтинг
+}
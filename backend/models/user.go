@@ -1,4 +1,3 @@
-
 package models
 
 import (
@@ -9,18 +8,24 @@ import (
 
 // User представляет модель пользователя
 type User struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Name      string             `bson:"name" json:"name"`
-	Email     string             `bson:"email" json:"email"`
-	Password  string             `bson:"password" json:"-"` // Не отдаем пароль в JSON
-	Title     string             `bson:"title" json:"title"`
-	Bio       string             `bson:"bio" json:"bio"`
-	Avatar    string             `bson:"avatar" json:"avatar"`
-	Skills    []string           `bson:"skills" json:"skills"`
-	Social    Social             `bson:"social" json:"social"`
-	CreatedAt time.Time          `bson:"created_at" json:"createdAt"`
-	UpdatedAt time.Time          `bson:"updated_at" json:"updatedAt"`
-	Rating    float64            `bson:"rating" json:"rating"`
+	ID               primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	Name             string               `bson:"name" json:"name"`
+	Email            string               `bson:"email" json:"email"`
+	Password         string               `bson:"password" json:"-"` // Не отдаем пароль в JSON
+	Title            string               `bson:"title" json:"title"`
+	Bio              string               `bson:"bio" json:"bio"`          // Markdown
+	BioHTML          string               `bson:"bio_html" json:"bioHtml"` // Безопасный HTML из Bio
+	Avatar           string               `bson:"avatar" json:"avatar"`
+	AvatarThumbnails map[string]string    `bson:"avatar_thumbnails,omitempty" json:"avatarThumbnails,omitempty"` // Уменьшенные копии аватара
+	Skills           []string             `bson:"skills" json:"skills"`
+	Social           Social               `bson:"social" json:"social"`
+	Privacy          PrivacySettings      `bson:"privacy" json:"privacy"`
+	PinnedProjects   []primitive.ObjectID `bson:"pinned_projects,omitempty" json:"pinnedProjects,omitempty"` // Закрепленные проекты в порядке закрепления
+	ProjectOrder     []primitive.ObjectID `bson:"project_order,omitempty" json:"projectOrder,omitempty"`     // Порядок проектов в профиле
+	EmailChange      *EmailChange         `bson:"email_change,omitempty" json:"-"`
+	CreatedAt        time.Time            `bson:"created_at" json:"createdAt"`
+	UpdatedAt        time.Time            `bson:"updated_at" json:"updatedAt"`
+	Rating           float64              `bson:"rating" json:"rating"`
 }
 
 // Social представляет социальные ссылки пользователя
@@ -30,3 +35,37 @@ type Social struct {
 	LinkedIn string `bson:"linkedin" json:"linkedin"`
 	Website  string `bson:"website" json:"website"`
 }
+
+// EmailChange хранит состояние смены email: ожидающий подтверждения адрес
+// и возможность отката для предыдущего адреса. Храним только хеши токенов.
+type EmailChange struct {
+	PendingEmail    string    `bson:"pending_email,omitempty"`
+	TokenHash       string    `bson:"token_hash,omitempty"`
+	ExpiresAt       time.Time `bson:"expires_at,omitempty"`
+	PreviousEmail   string    `bson:"previous_email,omitempty"`
+	RevertTokenHash string    `bson:"revert_token_hash,omitempty"`
+	RevertExpiresAt time.Time `bson:"revert_expires_at,omitempty"`
+}
+
+// PrivacySettings представляет настройки приватности профиля.
+// Нулевые значения соответствуют полностью публичному профилю.
+type PrivacySettings struct {
+	HideEmail      bool `bson:"hide_email" json:"hideEmail"`
+	HideSocial     bool `bson:"hide_social" json:"hideSocial"`
+	HideRating     bool `bson:"hide_rating" json:"hideRating"`
+	ResumeDisabled bool `bson:"resume_disabled" json:"resumeDisabled"`
+}
+
+// PublicView возвращает копию пользователя без полей, скрытых настройками приватности
+func (u User) PublicView() User {
+	if u.Privacy.HideEmail {
+		u.Email = ""
+	}
+	if u.Privacy.HideSocial {
+		u.Social = Social{}
+	}
+	if u.Privacy.HideRating {
+		u.Rating = 0
+	}
+	return u
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"your-project/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// countDailyActivity считает документы коллекции по проектам и дням (UTC), созданные начиная с since
+func countDailyActivity(ctx context.Context, collection *mongo.Collection, since time.Time) ([]models.DailyActivity, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"project_id": "$project_id",
+				"day":        bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$created_at"}},
+			},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":        0,
+			"project_id": "$_id.project_id",
+			"day":        "$_id.day",
+			"count":      1,
+		}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var activity []models.DailyActivity
+	if err = cursor.All(ctx, &activity); err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"your-project/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AnalyticsRepository представляет репозиторий для статистики просмотров
+type AnalyticsRepository struct {
+	visits    *mongo.Collection // уникальные посетители по дням
+	daily     *mongo.Collection // дневные счетчики просмотров
+	referrers *mongo.Collection // дневные счетчики источников переходов
+}
+
+// NewAnalyticsRepository создает новый репозиторий статистики просмотров
+func NewAnalyticsRepository(client *mongo.Client, dbName string) *AnalyticsRepository {
+	db := client.Database(dbName)
+	return &AnalyticsRepository{
+		visits:    db.Collection("view_visits"),
+		daily:     db.Collection("view_daily"),
+		referrers: db.Collection("view_referrers"),
+	}
+}
+
+// RegisterVisit отмечает посещение объекта посетителем за день.
+// Возвращает true, если посетитель еще не просматривал объект в этот день.
+// Время создания отметки нужно TTL-индексу, который удаляет устаревшие посещения.
+func (r *AnalyticsRepository) RegisterVisit(ctx context.Context, targetType string, targetID primitive.ObjectID, day, visitor string) (bool, error) {
+	filter := bson.M{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"day":         day,
+		"visitor":     visitor,
+	}
+
+	result, err := r.visits.UpdateOne(
+		ctx,
+		filter,
+		bson.M{"$setOnInsert": bson.M{"created_at": time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		// Параллельная вставка того же посещения нарушает уникальный индекс
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return result.UpsertedCount == 1, nil
+}
+
+// IncrementViews увеличивает дневной счетчик просмотров и счетчик источника перехода
+func (r *AnalyticsRepository) IncrementViews(ctx context.Context, targetType string, targetID primitive.ObjectID, day, referrer string) error {
+	_, err := r.daily.UpdateOne(
+		ctx,
+		bson.M{"target_type": targetType, "target_id": targetID, "day": day},
+		bson.M{"$inc": bson.M{"views": 1}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.referrers.UpdateOne(
+		ctx,
+		bson.M{"target_type": targetType, "target_id": targetID, "day": day, "referrer": referrer},
+		bson.M{"$inc": bson.M{"count": 1}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// FindDailyViews возвращает дневные счетчики просмотров за период (даты в формате YYYY-MM-DD)
+func (r *AnalyticsRepository) FindDailyViews(ctx context.Context, targetType string, targetID primitive.ObjectID, from, to string) ([]models.DailyViews, error) {
+	filter := bson.M{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"day":         bson.M{"$gte": from, "$lte": to},
+	}
+
+	cursor, err := r.daily.Find(ctx, filter, options.Find().SetSort(bson.M{"day": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var views []models.DailyViews
+	if err = cursor.All(ctx, &views); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+// FindDailyViewsSince возвращает дневные счетчики просмотров всех объектов типа начиная с дня from
+func (r *AnalyticsRepository) FindDailyViewsSince(ctx context.Context, targetType, from string) ([]models.DailyActivity, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"target_type": targetType,
+			"day":         bson.M{"$gte": from},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":        0,
+			"project_id": "$target_id",
+			"day":        1,
+			"count":      "$views",
+		}}},
+	}
+
+	cursor, err := r.daily.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var views []models.DailyActivity
+	if err = cursor.All(ctx, &views); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+// CountUniqueVisitors возвращает количество уникальных посетителей за период
+func (r *AnalyticsRepository) CountUniqueVisitors(ctx context.Context, targetType string, targetID primitive.ObjectID, from, to string) (int, error) {
+	visitors, err := r.visits.Distinct(ctx, "visitor", bson.M{
+		"target_type": targetType,
+		"target_id":   targetID,
+		"day":         bson.M{"$gte": from, "$lte": to},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(visitors), nil
+}
+
+// FindTopReferrers возвращает самые частые источники переходов за период
+func (r *AnalyticsRepository) FindTopReferrers(ctx context.Context, targetType string, targetID primitive.ObjectID, from, to string, limit int) ([]models.ReferrerCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"target_type": targetType,
+			"target_id":   targetID,
+			"day":         bson.M{"$gte": from, "$lte": to},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$referrer", "count": bson.M{"$sum": "$count"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.referrers.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var referrers []models.ReferrerCount
+	if err = cursor.All(ctx, &referrers); err != nil {
+		return nil, err
+	}
+
+	return referrers, nil
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"your-project/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrCategoryExists возвращается при создании категории с занятым slug
+var ErrCategoryExists = errors.New("category already exists")
+
+// CategoryRepository представляет репозиторий для работы с категориями проектов
+type CategoryRepository struct {
+	collection *mongo.Collection
+}
+
+// NewCategoryRepository создает новый репозиторий категорий
+func NewCategoryRepository(client *mongo.Client, dbName string) *CategoryRepository {
+	collection := client.Database(dbName).Collection("categories")
+	return &CategoryRepository{collection}
+}
+
+// FindAll возвращает все категории, упорядоченные по названию
+func (r *CategoryRepository) FindAll(ctx context.Context) ([]models.Category, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	categories := []models.Category{}
+	if err = cursor.All(ctx, &categories); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// FindBySlug находит категорию по slug
+func (r *CategoryRepository) FindBySlug(ctx context.Context, slug string) (models.Category, error) {
+	var category models.Category
+	err := r.collection.FindOne(ctx, bson.M{"slug": slug}).Decode(&category)
+	return category, err
+}
+
+// Create создает новую категорию (уникальность slug обеспечивается индексом)
+func (r *CategoryRepository) Create(ctx context.Context, category models.Category) (models.Category, error) {
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, category)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.Category{}, ErrCategoryExists
+	}
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	category.ID = result.InsertedID.(primitive.ObjectID)
+	return category, nil
+}
+
+// Update изменяет название и описание категории; slug не меняется
+func (r *CategoryRepository) Update(ctx context.Context, slug, name, description string) (models.Category, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var category models.Category
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"slug": slug},
+		bson.M{"$set": bson.M{
+			"name":        name,
+			"description": description,
+			"updated_at":  time.Now(),
+		}},
+		opts,
+	).Decode(&category)
+	return category, err
+}
+
+// Delete удаляет категорию. Возвращает false, если категории не было.
+func (r *CategoryRepository) Delete(ctx context.Context, slug string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"slug": slug})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
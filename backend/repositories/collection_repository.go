@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"your-project/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CollectionRepository представляет репозиторий для работы с подборками проектов
+type CollectionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewCollectionRepository создает новый репозиторий подборок
+func NewCollectionRepository(client *mongo.Client, dbName string) *CollectionRepository {
+	collection := client.Database(dbName).Collection("collections")
+	return &CollectionRepository{collection}
+}
+
+// FindByID находит подборку по ID
+func (r *CollectionRepository) FindByID(ctx context.Context, id string) (models.Collection, error) {
+	var collection models.Collection
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return collection, err
+	}
+
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&collection)
+	return collection, err
+}
+
+// FindByUserID возвращает подборки пользователя, начиная с новых; publicOnly оставляет только публичные
+func (r *CollectionRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID, publicOnly bool) ([]models.Collection, error) {
+	filter := bson.M{"user_id": userID}
+	if publicOnly {
+		filter["visibility"] = models.CollectionPublic
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	collections := []models.Collection{}
+	if err = cursor.All(ctx, &collections); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// CountByUserID возвращает количество подборок пользователя
+func (r *CollectionRepository) CountByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+// Create создает подборку
+func (r *CollectionRepository) Create(ctx context.Context, collection models.Collection) (models.Collection, error) {
+	collection.CreatedAt = time.Now()
+	collection.UpdatedAt = time.Now()
+	if collection.ProjectIDs == nil {
+		collection.ProjectIDs = []primitive.ObjectID{}
+	}
+
+	result, err := r.collection.InsertOne(ctx, collection)
+	if err != nil {
+		return collection, err
+	}
+
+	collection.ID = result.InsertedID.(primitive.ObjectID)
+	return collection, nil
+}
+
+// Update изменяет название, описание, видимость подборки и данные автора
+func (r *CollectionRepository) Update(ctx context.Context, collection models.Collection) (models.Collection, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated models.Collection
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": collection.ID},
+		bson.M{"$set": bson.M{
+			"name":        collection.Name,
+			"description": collection.Description,
+			"visibility":  collection.Visibility,
+			"user_name":   collection.UserName,
+			"user_avatar": collection.UserAvatar,
+			"updated_at":  time.Now(),
+		}},
+		opts,
+	).Decode(&updated)
+	return updated, err
+}
+
+// Delete удаляет подборку
+func (r *CollectionRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// AddProject добавляет проект в конец подборки, если в ней меньше maxProjects проектов.
+// Возвращает false, если подборка заполнена.
+func (r *CollectionRepository) AddProject(ctx context.Context, id, projectID primitive.ObjectID, maxProjects int) (bool, error) {
+	// Условие на отсутствие элемента с индексом maxProjects-1 делает проверку лимита атомарной
+	filter := bson.M{
+		"_id":         id,
+		"project_ids": bson.M{"$ne": projectID},
+		fmt.Sprintf("project_ids.%d", maxProjects-1): bson.M{"$exists": false},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{
+		"$push": bson.M{"project_ids": projectID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RemoveProject удаляет проект из подборки
+func (r *CollectionRepository) RemoveProject(ctx context.Context, id, projectID primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$pull": bson.M{"project_ids": projectID},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	return err
+}
+
+// SetProjectOrder сохраняет новый порядок проектов, если с момента чтения подборки
+// ее состав (current) не изменился. Возвращает false, если подборку успели изменить.
+func (r *CollectionRepository) SetProjectOrder(ctx context.Context, id primitive.ObjectID, current, order []primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "project_ids": current},
+		bson.M{"$set": bson.M{
+			"project_ids": order,
+			"updated_at":  time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RemoveProjectEverywhere удаляет проект из всех подборок (например, после удаления проекта)
+func (r *CollectionRepository) RemoveProjectEverywhere(ctx context.Context, projectID primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"project_ids": projectID},
+		bson.M{"$pull": bson.M{"project_ids": projectID}},
+	)
+	return err
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"your-project/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CommentRepository представляет репозиторий для работы с комментариями
+type CommentRepository struct {
+	collection *mongo.Collection
+}
+
+// NewCommentRepository создает новый репозиторий комментариев
+func NewCommentRepository(client *mongo.Client, dbName string) *CommentRepository {
+	collection := client.Database(dbName).Collection("comments")
+	return &CommentRepository{collection}
+}
+
+// FindByID находит комментарий по ID
+func (r *CommentRepository) FindByID(ctx context.Context, id string) (models.Comment, error) {
+	var comment models.Comment
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return comment, err
+	}
+
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&comment)
+	return comment, err
+}
+
+// FindPage возвращает до limit комментариев проекта с общим родителем (nil - верхний уровень)
+// в порядке создания, начиная после комментария after (если он задан)
+func (r *CommentRepository) FindPage(ctx context.Context, projectID primitive.ObjectID, parentID *primitive.ObjectID, after *primitive.ObjectID, limit int64) ([]models.Comment, error) {
+	filter := bson.M{"project_id": projectID, "parent_id": parentID}
+	if after != nil {
+		filter["_id"] = bson.M{"$gt": *after}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var comments []models.Comment
+	if err = cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// Create создает новый комментарий
+func (r *CommentRepository) Create(ctx context.Context, comment models.Comment) (models.Comment, error) {
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = comment.CreatedAt
+
+	result, err := r.collection.InsertOne(ctx, comment)
+	if err != nil {
+		return comment, err
+	}
+
+	comment.ID = result.InsertedID.(primitive.ObjectID)
+	return comment, nil
+}
+
+// UpdateContent обновляет текст комментария и отмечает его как отредактированный
+func (r *CommentRepository) UpdateContent(ctx context.Context, id primitive.ObjectID, content, contentHTML string) error {
+	now := time.Now()
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"content":      content,
+			"content_html": contentHTML,
+			"edited_at":    now,
+			"updated_at":   now,
+		}},
+	)
+	return err
+}
+
+// IncrementReplyCount атомарно изменяет счетчик ответов комментария на delta
+func (r *CommentRepository) IncrementReplyCount(ctx context.Context, id primitive.ObjectID, delta int) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"reply_count": delta}},
+	)
+	return err
+}
+
+// CountDailySince возвращает количество новых комментариев по проектам и дням, начиная с since
+func (r *CommentRepository) CountDailySince(ctx context.Context, since time.Time) ([]models.DailyActivity, error) {
+	return countDailyActivity(ctx, r.collection, since)
+}
+
+// Delete удаляет комментарий вместе с ответами на него и возвращает количество удаленных комментариев
+func (r *CommentRepository) Delete(ctx context.Context, id primitive.ObjectID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"$or": bson.A{
+		bson.M{"_id": id},
+		bson.M{"parent_id": id},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// DeleteByProjectID удаляет все комментарии и ответы удаленного проекта
+func (r *CommentRepository) DeleteByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"project_id": projectID})
+	return err
+}
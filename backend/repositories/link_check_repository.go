@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+
+	"your-project/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LinkCheckRepository представляет репозиторий для работы с результатами проверки ссылок
+type LinkCheckRepository struct {
+	collection *mongo.Collection
+}
+
+// NewLinkCheckRepository создает новый репозиторий проверок ссылок
+func NewLinkCheckRepository(client *mongo.Client, dbName string) *LinkCheckRepository {
+	collection := client.Database(dbName).Collection("link_checks")
+	return &LinkCheckRepository{collection}
+}
+
+// FindByUserID возвращает состояние ссылок пользователя: сначала ссылки профиля, затем проектов
+func (r *LinkCheckRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.LinkCheck, error) {
+	opts := options.Find().SetSort(bson.D{
+		{Key: "project_id", Value: 1},
+		{Key: "field", Value: 1},
+	})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	links := []models.LinkCheck{}
+	if err = cursor.All(ctx, &links); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// Save сохраняет состояние ссылки; ссылка определяется пользователем, проектом и полем
+func (r *LinkCheckRepository) Save(ctx context.Context, link models.LinkCheck) error {
+	link.ID = primitive.NilObjectID
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"user_id": link.UserID, "project_id": link.ProjectID, "field": link.Field},
+		link,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// DeleteByIDs удаляет состояние ссылок, которых больше нет в профиле или проектах
+func (r *LinkCheckRepository) DeleteByIDs(ctx context.Context, ids []primitive.ObjectID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
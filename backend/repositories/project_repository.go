@@ -3,6 +3,8 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"your-project/backend/models"
@@ -10,6 +12,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // ProjectRepository представляет репозиторий для работы с проектами
@@ -39,6 +42,199 @@ func (r *ProjectRepository) FindAll(ctx context.Context) ([]models.Project, erro
 	return projects, nil
 }
 
+// publishedFilter отбирает опубликованные проекты (проекты без статуса тоже считаются опубликованными)
+func publishedFilter(filter bson.M) bson.M {
+	filter["status"] = bson.M{"$in": bson.A{models.ProjectPublished, nil}}
+	return filter
+}
+
+// FindPublished возвращает все опубликованные проекты
+func (r *ProjectRepository) FindPublished(ctx context.Context) ([]models.Project, error) {
+	cursor, err := r.collection.Find(ctx, publishedFilter(bson.M{}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// Browse возвращает страницу опубликованных проектов, подходящих под фильтр, начиная с новых,
+// и фасеты. Количество по каждому фасету считается с учетом остальных фильтров, но без
+// фильтра по самому фасету, чтобы в нем оставались видны другие значения.
+func (r *ProjectRepository) Browse(ctx context.Context, filter models.ProjectFilter, skip, limit, facetLimit int) (models.ProjectBrowsePage, error) {
+	conditions := map[string]bson.M{}
+	if len(filter.Categories) > 0 {
+		conditions["category"] = bson.M{"$in": filter.Categories}
+	}
+	if len(filter.Tags) > 0 {
+		conditions["tags"] = bson.M{"$all": filter.Tags}
+	}
+	if len(filter.Technologies) > 0 {
+		conditions["technologies"] = bson.M{"$all": filter.Technologies}
+	}
+
+	// match возвращает условия всех фильтров, кроме фильтра по полю except
+	match := func(except string) bson.M {
+		result := bson.M{}
+		for field, condition := range conditions {
+			if field != except {
+				result[field] = condition
+			}
+		}
+		return result
+	}
+	// facet считает проекты по значениям поля; для массивов каждое значение учитывается отдельно
+	facet := func(field string, array bool) bson.A {
+		stages := bson.A{bson.M{"$match": match(field)}}
+		if array {
+			stages = append(stages, bson.M{"$unwind": "$" + field})
+		}
+		return append(stages,
+			bson.M{"$match": bson.M{field: bson.M{"$nin": bson.A{nil, ""}}}},
+			bson.M{"$group": bson.M{"_id": "$" + field, "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			bson.M{"$limit": facetLimit},
+		)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: publishedFilter(bson.M{})}},
+		{{Key: "$facet", Value: bson.M{
+			"projects": bson.A{
+				bson.M{"$match": match("")},
+				bson.M{"$sort": bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+				bson.M{"$skip": skip},
+				bson.M{"$limit": limit},
+			},
+			"total": bson.A{
+				bson.M{"$match": match("")},
+				bson.M{"$count": "count"},
+			},
+			"categories":   facet("category", false),
+			"tags":         facet("tags", true),
+			"technologies": facet("technologies", true),
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return models.ProjectBrowsePage{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Projects []models.Project `bson:"projects"`
+		Total    []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+		models.ProjectFacets `bson:",inline"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return models.ProjectBrowsePage{}, err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return models.ProjectBrowsePage{}, err
+	}
+
+	page := models.ProjectBrowsePage{
+		Projects: result.Projects,
+		Facets:   result.ProjectFacets,
+	}
+	if len(result.Total) > 0 {
+		page.Total = result.Total[0].Count
+	}
+	return page, nil
+}
+
+// FindWithoutDescriptionHTML возвращает проекты с описанием, для которого еще не сохранен HTML
+func (r *ProjectRepository) FindWithoutDescriptionHTML(ctx context.Context) ([]models.Project, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"description":      bson.M{"$nin": bson.A{"", nil}},
+		"description_html": bson.M{"$in": bson.A{"", nil}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// SetDescriptionHTML сохраняет HTML описания проекта
+func (r *ProjectRepository) SetDescriptionHTML(ctx context.Context, id primitive.ObjectID, descriptionHTML string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"description_html": descriptionHTML}},
+	)
+	return err
+}
+
+// CountTechnologies считает использование каждого написания технологии в опубликованных проектах
+func (r *ProjectRepository) CountTechnologies(ctx context.Context) ([]models.NameCount, error) {
+	return countArrayValues(ctx, r.collection, publishedFilter(bson.M{}), "technologies")
+}
+
+// ClearCategory снимает удаленную категорию с проектов
+func (r *ProjectRepository) ClearCategory(ctx context.Context, slug string) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"category": slug},
+		bson.M{"$set": bson.M{"category": ""}},
+	)
+	return err
+}
+
+// FindPublishedByStars возвращает опубликованные проекты, начиная с самых отмеченных
+func (r *ProjectRepository) FindPublishedByStars(ctx context.Context) ([]models.Project, error) {
+	opts := options.Find().SetSort(bson.D{
+		{Key: "star_count", Value: -1},
+		{Key: "created_at", Value: -1},
+	})
+
+	cursor, err := r.collection.Find(ctx, publishedFilter(bson.M{}), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// FindByIDs возвращает проекты с указанными ID (в произвольном порядке)
+func (r *ProjectRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Project, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
 // FindByID находит проект по ID
 func (r *ProjectRepository) FindByID(ctx context.Context, id string) (models.Project, error) {
 	var project models.Project
@@ -83,6 +279,259 @@ func (r *ProjectRepository) Update(ctx context.Context, id string, project model
 	return err
 }
 
+// UpdateImage обновляет только изображение проекта и его уменьшенные копии
+func (r *ProjectRepository) UpdateImage(ctx context.Context, id string, image string, thumbnails map[string]string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID},
+		bson.M{
+			"$set": bson.M{
+				"image":            image,
+				"image_thumbnails": thumbnails,
+				"updated_at":       time.Now(),
+			},
+			// Загруженное изображение больше не связано с элементом галереи
+			"$unset": bson.M{"cover_media_id": ""},
+		},
+	)
+	return err
+}
+
+// Patch обновляет только переданные поля проекта: set через $set, unset через $unset
+func (r *ProjectRepository) Patch(ctx context.Context, id string, set map[string]interface{}, unset []string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	fields := bson.M{"updated_at": time.Now()}
+	for name, value := range set {
+		fields[name] = value
+	}
+	update := bson.M{"$set": fields}
+
+	if len(unset) > 0 {
+		removed := bson.M{}
+		for _, name := range unset {
+			removed[name] = ""
+		}
+		update["$unset"] = removed
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+// AddMedia добавляет элемент в конец галереи проекта, если в ней меньше maxItems элементов.
+// Возвращает false, если галерея заполнена.
+func (r *ProjectRepository) AddMedia(ctx context.Context, id string, item models.MediaItem, maxItems int) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	// Условие на отсутствие элемента с индексом maxItems-1 делает проверку размера атомарной
+	filter := bson.M{
+		"_id":                                 objectID,
+		fmt.Sprintf("gallery.%d", maxItems-1): bson.M{"$exists": false},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{
+		"$push": bson.M{"gallery": item},
+		"$set":  bson.M{"updated_at": time.Now()},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
+// UpdateMedia обновляет подпись и альтернативный текст элемента галереи
+func (r *ProjectRepository) UpdateMedia(ctx context.Context, id string, mediaID primitive.ObjectID, caption, altText string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID, "gallery._id": mediaID},
+		bson.M{"$set": bson.M{
+			"gallery.$.caption":  caption,
+			"gallery.$.alt_text": altText,
+			"updated_at":         time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
+// ReplaceGallery сохраняет галерею проекта целиком (например, после изменения порядка)
+func (r *ProjectRepository) ReplaceGallery(ctx context.Context, id string, gallery []models.MediaItem) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID},
+		bson.M{"$set": bson.M{"gallery": gallery, "updated_at": time.Now()}},
+	)
+	return err
+}
+
+// SetCover выбирает обложку проекта; изображение проекта берется из элемента галереи
+func (r *ProjectRepository) SetCover(ctx context.Context, id string, item models.MediaItem) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID, "gallery._id": item.ID},
+		bson.M{"$set": bson.M{
+			"cover_media_id":   item.ID,
+			"image":            item.URL,
+			"image_thumbnails": item.Thumbnails,
+			"updated_at":       time.Now(),
+		}},
+	)
+	return err
+}
+
+// RemoveMedia удаляет элемент из галереи и возвращает его; если он был обложкой,
+// вместе с обложкой сбрасываются изображение проекта и его миниатюры
+func (r *ProjectRepository) RemoveMedia(ctx context.Context, id string, mediaID primitive.ObjectID) (models.MediaItem, bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return models.MediaItem{}, false, err
+	}
+
+	var before models.Project
+	err = r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": objectID, "gallery._id": mediaID},
+		bson.M{
+			"$pull": bson.M{"gallery": bson.M{"_id": mediaID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.Before),
+	).Decode(&before)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.MediaItem{}, false, nil
+	}
+	if err != nil {
+		return models.MediaItem{}, false, err
+	}
+
+	var removed models.MediaItem
+	for _, item := range before.Gallery {
+		if item.ID == mediaID {
+			removed = item
+			break
+		}
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID, "cover_media_id": mediaID},
+		bson.M{"$unset": bson.M{"cover_media_id": "", "image": "", "image_thumbnails": ""}},
+	)
+	return removed, true, err
+}
+
+// IncrementStarCount атомарно изменяет счетчик отметок проекта на delta
+func (r *ProjectRepository) IncrementStarCount(ctx context.Context, id primitive.ObjectID, delta int) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"star_count": delta}},
+	)
+	return err
+}
+
+// IncrementCommentCount атомарно изменяет счетчик комментариев проекта на delta
+func (r *ProjectRepository) IncrementCommentCount(ctx context.Context, id primitive.ObjectID, delta int) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"comment_count": delta}},
+	)
+	return err
+}
+
+// UpdateGitHubStats сохраняет метаданные репозитория проекта
+func (r *ProjectRepository) UpdateGitHubStats(ctx context.Context, id string, stats models.GitHubStats) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID},
+		bson.M{"$set": bson.M{"github": stats}},
+	)
+	return err
+}
+
+// MarkGitHubSyncFailed сохраняет причину неудачной синхронизации, не трогая ранее полученные данные
+func (r *ProjectRepository) MarkGitHubSyncFailed(ctx context.Context, id string, reason string, at time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID},
+		bson.M{"$set": bson.M{
+			"github.sync_error": reason,
+			"github.synced_at":  at,
+		}},
+	)
+	return err
+}
+
+// FindStaleGitHubProjects возвращает проекты со ссылкой на GitHub, которые не синхронизировались
+// с момента before, начиная с самых давних
+func (r *ProjectRepository) FindStaleGitHubProjects(ctx context.Context, before time.Time, limit int64) ([]models.Project, error) {
+	filter := bson.M{
+		"github_url": bson.M{"$nin": bson.A{"", nil}},
+		"$or": bson.A{
+			bson.M{"github.synced_at": bson.M{"$exists": false}},
+			bson.M{"github.synced_at": bson.M{"$lt": before}},
+		},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "github.synced_at", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
 // Delete удаляет проект
 func (r *ProjectRepository) Delete(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
@@ -115,9 +564,224 @@ func (r *ProjectRepository) FindByUserID(ctx context.Context, userID string) ([]
 	return projects, nil
 }
 
+// FindByMember находит проекты, которыми пользователь владеет или в которых участвует
+// (приглашение принято)
+func (r *ProjectRepository) FindByMember(ctx context.Context, userID string) ([]models.Project, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"user_id": objectID},
+		bson.M{"contributors": bson.M{"$elemMatch": bson.M{
+			"user_id": objectID,
+			"status":  models.ContributorAccepted,
+		}}},
+	}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// FindInvitations находит проекты, в которые пользователь приглашен, но еще не принял приглашение
+func (r *ProjectRepository) FindInvitations(ctx context.Context, userID string) ([]models.Project, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"contributors": bson.M{"$elemMatch": bson.M{
+		"user_id": objectID,
+		"status":  models.ContributorInvited,
+	}}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// AddContributor добавляет участника в проект. Возвращает false, если пользователь
+// уже владелец или участник проекта.
+func (r *ProjectRepository) AddContributor(ctx context.Context, id string, contributor models.Contributor) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{
+		"_id":                  objectID,
+		"user_id":              bson.M{"$ne": contributor.UserID},
+		"contributors.user_id": bson.M{"$ne": contributor.UserID},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{
+		"$push": bson.M{"contributors": contributor},
+		"$set":  bson.M{"updated_at": time.Now()},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// AcceptInvitation отмечает приглашение пользователя принятым. Возвращает false, если приглашения нет.
+func (r *ProjectRepository) AcceptInvitation(ctx context.Context, id string, userID primitive.ObjectID, at time.Time) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{
+		"_id": objectID,
+		"contributors": bson.M{"$elemMatch": bson.M{
+			"user_id": userID,
+			"status":  models.ContributorInvited,
+		}},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{
+		"contributors.$.status":      models.ContributorAccepted,
+		"contributors.$.accepted_at": at,
+	}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// UpdateContributorRole изменяет роль участника. Возвращает false, если участника нет.
+func (r *ProjectRepository) UpdateContributorRole(ctx context.Context, id string, userID primitive.ObjectID, role string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID, "contributors.user_id": userID},
+		bson.M{"$set": bson.M{
+			"contributors.$.role": role,
+			"updated_at":          time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RemoveContributor удаляет участника или приглашение. Возвращает false, если участника нет.
+func (r *ProjectRepository) RemoveContributor(ctx context.Context, id string, userID primitive.ObjectID) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID, "contributors.user_id": userID},
+		bson.M{
+			"$pull": bson.M{"contributors": bson.M{"user_id": userID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// TransferOwnership передает проект участнику newOwner, который должен принять приглашение.
+// Запись нового владельца в списке участников атомарно заменяется записью previousOwner.
+// Возвращает false, если владелец сменился или newOwner не является участником.
+func (r *ProjectRepository) TransferOwnership(ctx context.Context, id string, newOwner models.User, previousOwner models.Contributor) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{
+		"_id":     objectID,
+		"user_id": previousOwner.UserID,
+		"contributors": bson.M{"$elemMatch": bson.M{
+			"user_id": newOwner.ID,
+			"status":  models.ContributorAccepted,
+		}},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{
+		"user_id":        newOwner.ID,
+		"user_name":      newOwner.Name,
+		"user_avatar":    newOwner.Avatar,
+		"contributors.$": previousOwner,
+		"updated_at":     time.Now(),
+	}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// FindPublishDue возвращает черновики, время плановой публикации которых наступило
+func (r *ProjectRepository) FindPublishDue(ctx context.Context, now time.Time) ([]models.Project, error) {
+	cursor, err := r.collection.Find(ctx, publishDueFilter(bson.M{}, now))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// PublishDue публикует черновик id, если время его плановой публикации наступило.
+// Возвращает false, если проект уже опубликован или его публикация перенесена.
+func (r *ProjectRepository) PublishDue(ctx context.Context, id primitive.ObjectID, now time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		publishDueFilter(bson.M{"_id": id}, now),
+		bson.M{
+			"$set":   bson.M{"status": models.ProjectPublished, "updated_at": now},
+			"$unset": bson.M{"publish_at": ""},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// publishDueFilter дополняет фильтр условием наступившей плановой публикации черновика
+func publishDueFilter(filter bson.M, now time.Time) bson.M {
+	filter["status"] = models.ProjectDraft
+	filter["publish_at"] = bson.M{"$lte": now}
+	return filter
+}
+
 // SearchByTitle ищет проекты по заголовку
 func (r *ProjectRepository) SearchByTitle(ctx context.Context, title string) ([]models.Project, error) {
-	filter := bson.M{"title": bson.M{"$regex": title, "$options": "i"}}
+	filter := publishedFilter(bson.M{"title": bson.M{"$regex": title, "$options": "i"}})
 	cursor, err := r.collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -134,7 +798,7 @@ func (r *ProjectRepository) SearchByTitle(ctx context.Context, title string) ([]
 
 // SearchByTechnologies ищет проекты по технологиям
 func (r *ProjectRepository) SearchByTechnologies(ctx context.Context, technology string) ([]models.Project, error) {
-	filter := bson.M{"technologies": bson.M{"$regex": technology, "$options": "i"}}
+	filter := publishedFilter(bson.M{"technologies": bson.M{"$regex": technology, "$options": "i"}})
 	cursor, err := r.collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -148,3 +812,41 @@ func (r *ProjectRepository) SearchByTechnologies(ctx context.Context, technology
 
 	return projects, nil
 }
+
+// CountByUserID возвращает количество проектов пользователя
+func (r *ProjectRepository) CountByUserID(ctx context.Context, userID string) (int64, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": objectID})
+}
+
+// CountPerUser возвращает количество проектов для каждого пользователя
+func (r *ProjectRepository) CountPerUser(ctx context.Context) (map[primitive.ObjectID]int, error) {
+	groupStage := bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: "$user_id"},
+		{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+	}}}
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{groupStage})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		UserID primitive.ObjectID `bson:"_id"`
+		Count  int                `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[primitive.ObjectID]int, len(results))
+	for _, result := range results {
+		counts[result.UserID] = result.Count
+	}
+	return counts, nil
+}
@@ -83,6 +83,31 @@ func (r *ReviewRepository) Update(ctx context.Context, id string, review models.
 	return err
 }
 
+// Patch обновляет только переданные поля отзыва: set через $set, unset через $unset
+func (r *ReviewRepository) Patch(ctx context.Context, id string, set map[string]interface{}, unset []string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	fields := bson.M{"updated_at": time.Now()}
+	for name, value := range set {
+		fields[name] = value
+	}
+	update := bson.M{"$set": fields}
+
+	if len(unset) > 0 {
+		removed := bson.M{}
+		for _, name := range unset {
+			removed[name] = ""
+		}
+		update["$unset"] = removed
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
 // Delete удаляет отзыв
 func (r *ReviewRepository) Delete(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
@@ -122,11 +147,11 @@ func (r *ReviewRepository) CalculateAverageRating(ctx context.Context, userID st
 		return 0, err
 	}
 
-	matchStage := bson.D{{"$match", bson.D{{"user_id", objectID}}}}
+	matchStage := bson.D{{Key: "$match", Value: bson.D{{Key: "user_id", Value: objectID}}}}
 	groupStage := bson.D{
-		{"$group", bson.D{
-			{"_id", nil},
-			{"averageRating", bson.D{{"$avg", "$rating"}}},
+		{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "averageRating", Value: bson.D{{Key: "$avg", Value: "$rating"}}},
 		}},
 	}
 
@@ -147,3 +172,41 @@ func (r *ReviewRepository) CalculateAverageRating(ctx context.Context, userID st
 
 	return results[0]["averageRating"].(float64), nil
 }
+
+// CountByUserID возвращает количество отзывов пользователя
+func (r *ReviewRepository) CountByUserID(ctx context.Context, userID string) (int64, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": objectID})
+}
+
+// CountPerUser возвращает количество отзывов для каждого пользователя
+func (r *ReviewRepository) CountPerUser(ctx context.Context) (map[primitive.ObjectID]int, error) {
+	groupStage := bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: "$user_id"},
+		{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+	}}}
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{groupStage})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		UserID primitive.ObjectID `bson:"_id"`
+		Count  int                `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[primitive.ObjectID]int, len(results))
+	for _, result := range results {
+		counts[result.UserID] = result.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"your-project/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrRevisionExists возвращается, если версия с таким номером уже создана параллельным изменением
+var ErrRevisionExists = errors.New("revision already exists")
+
+// RevisionRepository представляет репозиторий истории версий проектов. Версии только добавляются.
+type RevisionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRevisionRepository создает новый репозиторий версий проектов
+func NewRevisionRepository(client *mongo.Client, dbName string) *RevisionRepository {
+	collection := client.Database(dbName).Collection("project_revisions")
+	return &RevisionRepository{collection}
+}
+
+// Create сохраняет новую версию (уникальность номера в проекте обеспечивается индексом)
+func (r *RevisionRepository) Create(ctx context.Context, revision models.ProjectRevision) (models.ProjectRevision, error) {
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now()
+	}
+
+	result, err := r.collection.InsertOne(ctx, revision)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.ProjectRevision{}, ErrRevisionExists
+	}
+	if err != nil {
+		return models.ProjectRevision{}, err
+	}
+
+	revision.ID = result.InsertedID.(primitive.ObjectID)
+	return revision, nil
+}
+
+// FindLatest возвращает последнюю версию проекта
+func (r *RevisionRepository) FindLatest(ctx context.Context, projectID primitive.ObjectID) (models.ProjectRevision, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "number", Value: -1}})
+
+	var revision models.ProjectRevision
+	err := r.collection.FindOne(ctx, bson.M{"project_id": projectID}, opts).Decode(&revision)
+	return revision, err
+}
+
+// FindByNumber находит версию проекта по номеру
+func (r *RevisionRepository) FindByNumber(ctx context.Context, projectID primitive.ObjectID, number int) (models.ProjectRevision, error) {
+	var revision models.ProjectRevision
+	err := r.collection.FindOne(ctx, bson.M{"project_id": projectID, "number": number}).Decode(&revision)
+	return revision, err
+}
+
+// FindByProjectID возвращает версии проекта, начиная с новых
+func (r *RevisionRepository) FindByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]models.ProjectRevision, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "number", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"project_id": projectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []models.ProjectRevision{}
+	if err = cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// DeleteByProjectID удаляет историю версий удаленного проекта
+func (r *RevisionRepository) DeleteByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"project_id": projectID})
+	return err
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"your-project/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// StarRepository представляет репозиторий для работы с отметками проектов
+type StarRepository struct {
+	collection *mongo.Collection
+}
+
+// NewStarRepository создает новый репозиторий отметок
+func NewStarRepository(client *mongo.Client, dbName string) *StarRepository {
+	collection := client.Database(dbName).Collection("stars")
+	return &StarRepository{collection}
+}
+
+// Create сохраняет отметку. Возвращает false, если пользователь уже отметил проект
+// (уникальность обеспечивается индексом по project_id и user_id).
+func (r *StarRepository) Create(ctx context.Context, star models.Star) (bool, error) {
+	star.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, star)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Delete удаляет отметку пользователя. Возвращает false, если отметки не было.
+func (r *StarRepository) Delete(ctx context.Context, projectID, userID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"project_id": projectID, "user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// DeleteByProjectID удаляет все отметки проекта
+func (r *StarRepository) DeleteByProjectID(ctx context.Context, projectID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"project_id": projectID})
+	return err
+}
+
+// FindByProjectID возвращает отметки проекта, начиная с новых
+func (r *StarRepository) FindByProjectID(ctx context.Context, projectID primitive.ObjectID) ([]models.Star, error) {
+	return r.find(ctx, bson.M{"project_id": projectID})
+}
+
+// FindByUserID возвращает отметки пользователя, начиная с новых
+func (r *StarRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.Star, error) {
+	return r.find(ctx, bson.M{"user_id": userID})
+}
+
+// Exists проверяет, отметил ли пользователь проект
+func (r *StarRepository) Exists(ctx context.Context, projectID, userID primitive.ObjectID) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"project_id": projectID, "user_id": userID})
+	return count > 0, err
+}
+
+// CountDailySince возвращает количество новых отметок по проектам и дням, начиная с since
+func (r *StarRepository) CountDailySince(ctx context.Context, since time.Time) ([]models.DailyActivity, error) {
+	return countDailyActivity(ctx, r.collection, since)
+}
+
+// find возвращает отметки по фильтру, начиная с новых
+func (r *StarRepository) find(ctx context.Context, filter bson.M) ([]models.Star, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stars []models.Star
+	if err = cursor.All(ctx, &stars); err != nil {
+		return nil, err
+	}
+
+	return stars, nil
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"your-project/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TechnologyRepository представляет репозиторий технологий для автодополнения
+type TechnologyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewTechnologyRepository создает новый репозиторий технологий
+func NewTechnologyRepository(client *mongo.Client, dbName string) *TechnologyRepository {
+	collection := client.Database(dbName).Collection("technologies")
+	return &TechnologyRepository{collection}
+}
+
+// Search возвращает технологии, одно из написаний которых начинается с prefix, начиная с самых популярных
+func (r *TechnologyRepository) Search(ctx context.Context, prefix string, limit int64) ([]models.Technology, error) {
+	filter := bson.M{}
+	if prefix != "" {
+		filter["terms"] = bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	technologies := []models.Technology{}
+	if err = cursor.All(ctx, &technologies); err != nil {
+		return nil, err
+	}
+
+	return technologies, nil
+}
+
+// ReplaceAll сохраняет технологии, полученные при пересчете в момент refreshedAt,
+// и удаляет технологии, которые больше не используются
+func (r *TechnologyRepository) ReplaceAll(ctx context.Context, technologies []models.Technology, refreshedAt time.Time) error {
+	if len(technologies) > 0 {
+		writes := make([]mongo.WriteModel, 0, len(technologies))
+		for _, technology := range technologies {
+			technology.RefreshedAt = refreshedAt
+			writes = append(writes, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"_id": technology.Key}).
+				SetReplacement(technology).
+				SetUpsert(true))
+		}
+		if _, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"refreshed_at": bson.M{"$lt": refreshedAt}})
+	return err
+}
+
+// countArrayValues считает, сколько раз каждое значение поля-массива field встречается в документах, отобранных match
+func countArrayValues(ctx context.Context, collection *mongo.Collection, match bson.M, field string) ([]models.NameCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$" + field}},
+		{{Key: "$match", Value: bson.M{field: bson.M{"$type": "string"}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$" + field,
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var counts []models.NameCount
+	if err = cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
@@ -3,6 +3,8 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"your-project/backend/models"
@@ -12,6 +14,10 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// ErrEmailExists возвращается, если email уже принадлежит другому пользователю
+// (уникальность обеспечивается индексом по email)
+var ErrEmailExists = errors.New("email is already in use")
+
 // UserRepository представляет репозиторий для работы с пользователями
 type UserRepository struct {
 	collection *mongo.Collection
@@ -58,6 +64,9 @@ func (r *UserRepository) Create(ctx context.Context, user models.User) (models.U
 	user.UpdatedAt = time.Now()
 
 	result, err := r.collection.InsertOne(ctx, user)
+	if mongo.IsDuplicateKeyError(err) {
+		return user, ErrEmailExists
+	}
 	if err != nil {
 		return user, err
 	}
@@ -83,6 +92,205 @@ func (r *UserRepository) Update(ctx context.Context, id string, user models.User
 	return err
 }
 
+// UpdateAvatar обновляет только аватар пользователя и его уменьшенные копии
+func (r *UserRepository) UpdateAvatar(ctx context.Context, id string, avatar string, thumbnails map[string]string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID},
+		bson.M{"$set": bson.M{
+			"avatar":            avatar,
+			"avatar_thumbnails": thumbnails,
+			"updated_at":        time.Now(),
+		}},
+	)
+	return err
+}
+
+// SetEmailChange сохраняет состояние смены email (nil удаляет его)
+func (r *UserRepository) SetEmailChange(ctx context.Context, id string, change *models.EmailChange) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$unset": bson.M{"email_change": ""}}
+	if change != nil {
+		update = bson.M{"$set": bson.M{"email_change": change}}
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
+// UpdateEmail меняет email пользователя и состояние смены email
+func (r *UserRepository) UpdateEmail(ctx context.Context, id string, email string, change *models.EmailChange) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	set := bson.M{"email": email, "updated_at": time.Now()}
+	update := bson.M{"$set": set}
+	if change != nil {
+		set["email_change"] = change
+	} else {
+		update["$unset"] = bson.M{"email_change": ""}
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrEmailExists
+	}
+	return err
+}
+
+// FindByEmailChangeToken находит пользователя по хешу токена подтверждения нового email
+func (r *UserRepository) FindByEmailChangeToken(ctx context.Context, tokenHash string) (models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{"email_change.token_hash": tokenHash}).Decode(&user)
+	return user, err
+}
+
+// FindByEmailRevertToken находит пользователя по хешу токена отката смены email
+func (r *UserRepository) FindByEmailRevertToken(ctx context.Context, tokenHash string) (models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{"email_change.revert_token_hash": tokenHash}).Decode(&user)
+	return user, err
+}
+
+// FindWithoutBioHTML возвращает пользователей с биографией, для которой еще не сохранен HTML
+func (r *UserRepository) FindWithoutBioHTML(ctx context.Context) ([]models.User, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"bio":      bson.M{"$nin": bson.A{"", nil}},
+		"bio_html": bson.M{"$in": bson.A{"", nil}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// SetBioHTML сохраняет HTML биографии пользователя
+func (r *UserRepository) SetBioHTML(ctx context.Context, id primitive.ObjectID, bioHTML string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"bio_html": bioHTML}},
+	)
+	return err
+}
+
+// CountSkills считает использование каждого написания навыка в профилях
+func (r *UserRepository) CountSkills(ctx context.Context) ([]models.NameCount, error) {
+	return countArrayValues(ctx, r.collection, bson.M{}, "skills")
+}
+
+// PinProject закрепляет проект в профиле, если закреплено меньше maxPinned проектов.
+// Закрепления проектов, которых нет среди validIDs (удаленных или недоступных), снимаются.
+// Возвращает false, если лимит закреплений исчерпан.
+func (r *UserRepository) PinProject(ctx context.Context, id string, projectID primitive.ObjectID, validIDs []primitive.ObjectID, maxPinned int) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID},
+		bson.M{"$pull": bson.M{"pinned_projects": bson.M{"$nin": validIDs}}},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// Условие на отсутствие элемента с индексом maxPinned-1 делает проверку лимита атомарной
+	filter := bson.M{
+		"_id": objectID,
+		fmt.Sprintf("pinned_projects.%d", maxPinned-1): bson.M{"$exists": false},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{
+		"$addToSet": bson.M{"pinned_projects": projectID},
+		"$set":      bson.M{"updated_at": time.Now()},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// UnpinProject снимает закрепление проекта в профиле
+func (r *UserRepository) UnpinProject(ctx context.Context, id string, projectID primitive.ObjectID) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID},
+		bson.M{
+			"$pull": bson.M{"pinned_projects": projectID},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	return err
+}
+
+// SetProjectOrder сохраняет порядок проектов в профиле
+func (r *UserRepository) SetProjectOrder(ctx context.Context, id string, order []primitive.ObjectID) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objectID},
+		bson.M{"$set": bson.M{
+			"project_order": order,
+			"updated_at":    time.Now(),
+		}},
+	)
+	return err
+}
+
+// Patch обновляет только переданные поля пользователя: set через $set, unset через $unset
+func (r *UserRepository) Patch(ctx context.Context, id string, set map[string]interface{}, unset []string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	fields := bson.M{"updated_at": time.Now()}
+	for name, value := range set {
+		fields[name] = value
+	}
+	update := bson.M{"$set": fields}
+
+	if len(unset) > 0 {
+		removed := bson.M{}
+		for _, name := range unset {
+			removed[name] = ""
+		}
+		update["$unset"] = removed
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	return err
+}
+
 // Delete удаляет пользователя
 func (r *UserRepository) Delete(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// DefaultAnalyticsDays период статистики по умолчанию
+	DefaultAnalyticsDays = 30
+	// MaxAnalyticsDays максимальный период статистики
+	MaxAnalyticsDays = 365
+	// VisitRetention срок хранения отметок о посещении. Они отсеивают повторные просмотры за день и
+	// по ним считаются уникальные посетители за период, поэтому хранятся весь максимальный период статистики
+	// и еще сутки, в течение которых день отметки может быть текущим
+	VisitRetention = (MaxAnalyticsDays + 1) * 24 * time.Hour
+	// topReferrersLimit количество источников переходов в статистике
+	topReferrersLimit = 10
+	// viewRecordTimeout время на запись просмотра в фоне
+	viewRecordTimeout = 5 * time.Second
+	// analyticsDayLayout формат дня в дневных счетчиках
+	analyticsDayLayout = "2006-01-02"
+	// directReferrer источник для переходов без заголовка Referer
+	directReferrer = "direct"
+)
+
+// botUserAgentMarkers подстроки User-Agent, по которым запросы считаются автоматическими
+var botUserAgentMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "preview", "facebookexternalhit",
+	"curl", "wget", "python-requests", "go-http-client", "headless", "lighthouse",
+}
+
+// Visitor представляет данные посетителя, необходимые для учета просмотра
+type Visitor struct {
+	UserID    string
+	IP        string
+	UserAgent string
+	Referrer  string
+}
+
+// AnalyticsService учитывает просмотры профилей и проектов и формирует статистику
+type AnalyticsService struct {
+	analyticsRepo *repositories.AnalyticsRepository
+	salt          string
+	now           func() time.Time
+}
+
+// NewAnalyticsService создает новый сервис статистики просмотров.
+// salt добавляется к идентификатору посетителя перед хешированием, чтобы не хранить IP-адреса.
+func NewAnalyticsService(analyticsRepo *repositories.AnalyticsRepository, salt string) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		salt:          salt,
+		now:           time.Now,
+	}
+}
+
+// TrackView учитывает просмотр в фоне, не задерживая ответ клиенту.
+// Просмотры владельца и ботов не учитываются, повторные просмотры за день - тоже.
+func (s *AnalyticsService) TrackView(targetType string, targetID primitive.ObjectID, ownerID primitive.ObjectID, visitor Visitor) {
+	if IsBot(visitor.UserAgent) {
+		return
+	}
+	if visitor.UserID != "" && visitor.UserID == ownerID.Hex() {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), viewRecordTimeout)
+		defer cancel()
+
+		if err := s.RecordView(ctx, targetType, targetID, visitor); err != nil {
+			log.Printf("Failed to record %s view: %v", targetType, err)
+		}
+	}()
+}
+
+// RecordView сохраняет просмотр, если посетитель еще не просматривал объект сегодня
+func (s *AnalyticsService) RecordView(ctx context.Context, targetType string, targetID primitive.ObjectID, visitor Visitor) error {
+	day := s.now().UTC().Format(analyticsDayLayout)
+
+	isNew, err := s.analyticsRepo.RegisterVisit(ctx, targetType, targetID, day, s.visitorKey(visitor))
+	if err != nil || !isNew {
+		return err
+	}
+
+	return s.analyticsRepo.IncrementViews(ctx, targetType, targetID, day, referrerHost(visitor.Referrer))
+}
+
+// GetAnalytics возвращает статистику просмотров за последние days дней
+func (s *AnalyticsService) GetAnalytics(ctx context.Context, targetType string, targetID primitive.ObjectID, days int) (models.ViewAnalytics, error) {
+	if days <= 0 {
+		days = DefaultAnalyticsDays
+	}
+	if days > MaxAnalyticsDays {
+		days = MaxAnalyticsDays
+	}
+
+	today := s.now().UTC().Truncate(24 * time.Hour)
+	start := today.AddDate(0, 0, -(days - 1))
+	from, to := start.Format(analyticsDayLayout), today.Format(analyticsDayLayout)
+
+	daily, err := s.analyticsRepo.FindDailyViews(ctx, targetType, targetID, from, to)
+	if err != nil {
+		return models.ViewAnalytics{}, err
+	}
+
+	unique, err := s.analyticsRepo.CountUniqueVisitors(ctx, targetType, targetID, from, to)
+	if err != nil {
+		return models.ViewAnalytics{}, err
+	}
+
+	referrers, err := s.analyticsRepo.FindTopReferrers(ctx, targetType, targetID, from, to, topReferrersLimit)
+	if err != nil {
+		return models.ViewAnalytics{}, err
+	}
+	if referrers == nil {
+		referrers = []models.ReferrerCount{}
+	}
+
+	// Временной ряд содержит все дни периода, в том числе без просмотров
+	byDay := make(map[string]int, len(daily))
+	for _, d := range daily {
+		byDay[d.Date] = d.Views
+	}
+
+	result := models.ViewAnalytics{
+		From:           from,
+		To:             to,
+		UniqueVisitors: unique,
+		Referrers:      referrers,
+		Series:         make([]models.DailyViews, 0, days),
+	}
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format(analyticsDayLayout)
+		result.Series = append(result.Series, models.DailyViews{Date: date, Views: byDay[date]})
+		result.TotalViews += byDay[date]
+	}
+
+	return result, nil
+}
+
+// visitorKey возвращает обезличенный идентификатор посетителя
+func (s *AnalyticsService) visitorKey(visitor Visitor) string {
+	identity := "anon:" + visitor.IP + "|" + visitor.UserAgent
+	if visitor.UserID != "" {
+		identity = "user:" + visitor.UserID
+	}
+
+	sum := sha256.Sum256([]byte(s.salt + "|" + identity))
+	return hex.EncodeToString(sum[:16])
+}
+
+// IsBot определяет автоматические запросы по User-Agent
+func IsBot(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return true
+	}
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(ua, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// referrerHost возвращает хост источника перехода
+func referrerHost(referrer string) string {
+	if referrer == "" {
+		return directReferrer
+	}
+	parsed, err := url.Parse(referrer)
+	if err != nil || parsed.Hostname() == "" {
+		return directReferrer
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"your-project/backend/models"
+)
+
+const (
+	// MaxCaseStudySectionLength максимальная длина раздела подробного описания в символах
+	MaxCaseStudySectionLength = 10000
+	// MaxCaseStudyMetrics максимальное количество метрик в разделе results
+	MaxCaseStudyMetrics = 10
+	// MaxCaseStudyMetricLength максимальная длина названия или значения метрики в символах
+	MaxCaseStudyMetricLength = 100
+)
+
+// ErrInvalidCaseStudy возвращается для некорректных разделов подробного описания проекта
+var ErrInvalidCaseStudy = errors.New("invalid case study")
+
+// caseStudyTitles заголовки разделов подробного описания в экспорте
+var caseStudyTitles = map[string]string{
+	models.CaseStudyProblem:      "Problem",
+	models.CaseStudyApproach:     "Approach",
+	models.CaseStudyArchitecture: "Architecture",
+	models.CaseStudyResults:      "Results",
+	models.CaseStudyLessons:      "Lessons learned",
+	models.CaseStudyRole:         "Role",
+}
+
+// prepareCaseStudy проверяет разделы подробного описания проекта, удаляет пустые,
+// упорядочивает их по models.CaseStudySections и сохраняет HTML, полученный из Markdown
+func prepareCaseStudy(project *models.Project) error {
+	if len(project.CaseStudy) == 0 {
+		project.CaseStudy = nil
+		return nil
+	}
+
+	byType := make(map[string]models.CaseStudySection, len(project.CaseStudy))
+	for _, section := range project.CaseStudy {
+		section.Type = strings.ToLower(strings.TrimSpace(section.Type))
+		if _, known := caseStudyTitles[section.Type]; !known {
+			return fmt.Errorf("%w: unknown section type %q", ErrInvalidCaseStudy, section.Type)
+		}
+		if _, duplicate := byType[section.Type]; duplicate {
+			return fmt.Errorf("%w: duplicate section %q", ErrInvalidCaseStudy, section.Type)
+		}
+
+		section.Content = strings.TrimSpace(section.Content)
+		if utf8.RuneCountInString(section.Content) > MaxCaseStudySectionLength {
+			return fmt.Errorf("%w: section %q must be at most %d characters", ErrInvalidCaseStudy, section.Type, MaxCaseStudySectionLength)
+		}
+
+		metrics, err := normalizeCaseStudyMetrics(section)
+		if err != nil {
+			return err
+		}
+		section.Metrics = metrics
+
+		rendered, err := RenderMarkdown(section.Content)
+		if err != nil {
+			return err
+		}
+		section.ContentHTML = rendered
+		byType[section.Type] = section
+	}
+
+	sections := make([]models.CaseStudySection, 0, len(byType))
+	for _, sectionType := range models.CaseStudySections {
+		section, ok := byType[sectionType]
+		if ok && (section.Content != "" || len(section.Metrics) > 0) {
+			sections = append(sections, section)
+		}
+	}
+	if len(sections) == 0 {
+		sections = nil
+	}
+	project.CaseStudy = sections
+	return nil
+}
+
+// normalizeCaseStudyMetrics проверяет метрики раздела: они допустимы только в разделе results
+func normalizeCaseStudyMetrics(section models.CaseStudySection) ([]models.CaseStudyMetric, error) {
+	if len(section.Metrics) == 0 {
+		return nil, nil
+	}
+	if section.Type != models.CaseStudyResults {
+		return nil, fmt.Errorf("%w: metrics are allowed only in the %q section", ErrInvalidCaseStudy, models.CaseStudyResults)
+	}
+	if len(section.Metrics) > MaxCaseStudyMetrics {
+		return nil, fmt.Errorf("%w: at most %d metrics are allowed", ErrInvalidCaseStudy, MaxCaseStudyMetrics)
+	}
+
+	metrics := make([]models.CaseStudyMetric, 0, len(section.Metrics))
+	for _, metric := range section.Metrics {
+		metric.Label = strings.TrimSpace(metric.Label)
+		metric.Value = strings.TrimSpace(metric.Value)
+		if metric.Label == "" || metric.Value == "" {
+			return nil, fmt.Errorf("%w: metric label and value are required", ErrInvalidCaseStudy)
+		}
+		if utf8.RuneCountInString(metric.Label) > MaxCaseStudyMetricLength || utf8.RuneCountInString(metric.Value) > MaxCaseStudyMetricLength {
+			return nil, fmt.Errorf("%w: metric label and value must be at most %d characters", ErrInvalidCaseStudy, MaxCaseStudyMetricLength)
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, nil
+}
+
+// caseStudyTitle возвращает заголовок раздела подробного описания для экспорта
+func caseStudyTitle(sectionType string) string {
+	if title, ok := caseStudyTitles[sectionType]; ok {
+		return title
+	}
+	return sectionType
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrInvalidCategory возвращается для категории с некорректным slug или названием
+	ErrInvalidCategory = errors.New("category slug must be lowercase letters, digits and dashes, and name must be between 1 and 60 characters")
+	// ErrCategoryNotFound возвращается, если категории с таким slug нет
+	ErrCategoryNotFound = errors.New("category not found")
+)
+
+// categorySlugPattern допустимый slug категории, например web-app
+var categorySlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// CategoryService представляет сервис категорий проектов
+type CategoryService struct {
+	categoryRepo *repositories.CategoryRepository
+	projectRepo  *repositories.ProjectRepository
+}
+
+// NewCategoryService создает новый сервис категорий
+func NewCategoryService(categoryRepo *repositories.CategoryRepository, projectRepo *repositories.ProjectRepository) *CategoryService {
+	return &CategoryService{
+		categoryRepo: categoryRepo,
+		projectRepo:  projectRepo,
+	}
+}
+
+// GetCategories возвращает все категории
+func (s *CategoryService) GetCategories(ctx context.Context) ([]models.Category, error) {
+	return s.categoryRepo.FindAll(ctx)
+}
+
+// CreateCategory создает категорию
+func (s *CategoryService) CreateCategory(ctx context.Context, category models.Category) (models.Category, error) {
+	category.Slug = strings.ToLower(strings.TrimSpace(category.Slug))
+	category.Name = strings.TrimSpace(category.Name)
+	category.Description = strings.TrimSpace(category.Description)
+	if len(category.Slug) > 40 || !categorySlugPattern.MatchString(category.Slug) || !validCategoryName(category.Name) {
+		return models.Category{}, ErrInvalidCategory
+	}
+
+	return s.categoryRepo.Create(ctx, category)
+}
+
+// UpdateCategory изменяет название и описание категории
+func (s *CategoryService) UpdateCategory(ctx context.Context, slug, name, description string) (models.Category, error) {
+	name = strings.TrimSpace(name)
+	if !validCategoryName(name) {
+		return models.Category{}, ErrInvalidCategory
+	}
+
+	category, err := s.categoryRepo.Update(ctx, slug, name, strings.TrimSpace(description))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.Category{}, ErrCategoryNotFound
+	}
+	return category, err
+}
+
+// DeleteCategory удаляет категорию и снимает ее с проектов
+func (s *CategoryService) DeleteCategory(ctx context.Context, slug string) error {
+	deleted, err := s.categoryRepo.Delete(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrCategoryNotFound
+	}
+
+	return s.projectRepo.ClearCategory(ctx, slug)
+}
+
+// validCategoryName проверяет длину названия категории
+func validCategoryName(name string) bool {
+	length := utf8.RuneCountInString(name)
+	return length > 0 && length <= 60
+}
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// MaxCollectionsPerUser максимальное количество подборок у пользователя
+	MaxCollectionsPerUser = 50
+	// MaxCollectionProjects максимальное количество проектов в подборке
+	MaxCollectionProjects = 100
+	// MaxCollectionNameLength максимальная длина названия подборки в символах
+	MaxCollectionNameLength = 100
+	// MaxCollectionDescriptionLength максимальная длина описания подборки в символах
+	MaxCollectionDescriptionLength = 1000
+)
+
+var (
+	// ErrCollectionNotFound возвращается для несуществующей или недоступной пользователю подборки
+	ErrCollectionNotFound = errors.New("collection not found")
+	// ErrInvalidCollection возвращается для некорректных названия, описания или видимости подборки
+	ErrInvalidCollection = errors.New("invalid collection")
+	// ErrCollectionLimitReached возвращается при попытке создать больше MaxCollectionsPerUser подборок
+	ErrCollectionLimitReached = fmt.Errorf("you can have at most %d collections", MaxCollectionsPerUser)
+	// ErrCollectionFull возвращается при попытке добавить больше MaxCollectionProjects проектов
+	ErrCollectionFull = fmt.Errorf("a collection can have at most %d projects", MaxCollectionProjects)
+	// ErrProjectUnavailable возвращается для несуществующего проекта или черновика, недоступного автору подборки
+	ErrProjectUnavailable = errors.New("project not found")
+	// ErrInvalidCollectionOrder возвращается, если новый порядок не совпадает по составу с проектами подборки
+	ErrInvalidCollectionOrder = errors.New("project order must list every project in the collection exactly once")
+)
+
+// CollectionService представляет сервис подборок проектов
+type CollectionService struct {
+	collectionRepo *repositories.CollectionRepository
+	projectRepo    *repositories.ProjectRepository
+	userRepo       *repositories.UserRepository
+}
+
+// NewCollectionService создает новый сервис подборок
+func NewCollectionService(collectionRepo *repositories.CollectionRepository, projectRepo *repositories.ProjectRepository, userRepo *repositories.UserRepository) *CollectionService {
+	return &CollectionService{
+		collectionRepo: collectionRepo,
+		projectRepo:    projectRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// GetCollection возвращает подборку; приватная подборка доступна только ее автору
+func (s *CollectionService) GetCollection(ctx context.Context, id, viewerID string) (models.Collection, error) {
+	collection, err := s.collectionRepo.FindByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return collection, ErrCollectionNotFound
+	}
+	if err != nil {
+		return collection, err
+	}
+	if !collection.IsVisibleTo(viewerID) {
+		return collection, ErrCollectionNotFound
+	}
+	return collection, nil
+}
+
+// GetCollectionPage возвращает подборку вместе с проектами в ее порядке. Удаленные проекты,
+// а также черновики и архивные проекты, которые зритель не может видеть, пропускаются.
+func (s *CollectionService) GetCollectionPage(ctx context.Context, id, viewerID string) (models.CollectionPage, error) {
+	collection, err := s.GetCollection(ctx, id, viewerID)
+	if err != nil {
+		return models.CollectionPage{}, err
+	}
+	return s.collectionPage(ctx, collection, viewerID)
+}
+
+// GetUserCollections возвращает подборки пользователя; приватные видит только он сам
+func (s *CollectionService) GetUserCollections(ctx context.Context, userID, viewerID string) ([]models.Collection, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.collectionRepo.FindByUserID(ctx, objectID, userID != viewerID)
+}
+
+// CreateCollection создает пустую подборку пользователя
+func (s *CollectionService) CreateCollection(ctx context.Context, userID string, collection models.Collection) (models.Collection, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return models.Collection{}, err
+	}
+	if err := prepareCollection(&collection); err != nil {
+		return models.Collection{}, err
+	}
+
+	count, err := s.collectionRepo.CountByUserID(ctx, user.ID)
+	if err != nil {
+		return models.Collection{}, err
+	}
+	if count >= MaxCollectionsPerUser {
+		return models.Collection{}, ErrCollectionLimitReached
+	}
+
+	collection.ID = primitive.NilObjectID
+	collection.UserID = user.ID
+	collection.UserName = user.Name
+	collection.UserAvatar = user.Avatar
+	collection.ProjectIDs = nil
+	return s.collectionRepo.Create(ctx, collection)
+}
+
+// UpdateCollection изменяет название, описание и видимость подборки
+func (s *CollectionService) UpdateCollection(ctx context.Context, existing, changes models.Collection) (models.Collection, error) {
+	if err := prepareCollection(&changes); err != nil {
+		return models.Collection{}, err
+	}
+
+	existing.Name = changes.Name
+	existing.Description = changes.Description
+	existing.Visibility = changes.Visibility
+
+	// Обновить информацию об авторе в подборке
+	if user, err := s.userRepo.FindByID(ctx, existing.UserID.Hex()); err == nil {
+		existing.UserName = user.Name
+		existing.UserAvatar = user.Avatar
+	}
+	return s.collectionRepo.Update(ctx, existing)
+}
+
+// DeleteCollection удаляет подборку; проекты при этом не затрагиваются
+func (s *CollectionService) DeleteCollection(ctx context.Context, collection models.Collection) error {
+	return s.collectionRepo.Delete(ctx, collection.ID)
+}
+
+// AddProject добавляет проект в конец подборки и возвращает подборку с проектами. Добавить можно
+// любой опубликованный проект, а черновик или архивный проект - только его участнику.
+func (s *CollectionService) AddProject(ctx context.Context, collection models.Collection, projectID string) (models.CollectionPage, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return models.CollectionPage{}, ErrProjectUnavailable
+	}
+	if err != nil {
+		return models.CollectionPage{}, err
+	}
+	ownerID := collection.UserID.Hex()
+	if !project.IsVisibleTo(ownerID) {
+		return models.CollectionPage{}, ErrProjectUnavailable
+	}
+
+	if indexOfObjectID(collection.ProjectIDs, project.ID) < 0 {
+		added, err := s.collectionRepo.AddProject(ctx, collection.ID, project.ID, MaxCollectionProjects)
+		if err != nil {
+			return models.CollectionPage{}, err
+		}
+		if !added {
+			// Проект мог быть добавлен параллельным запросом; иначе подборка заполнена
+			current, err := s.collectionRepo.FindByID(ctx, collection.ID.Hex())
+			if err != nil {
+				return models.CollectionPage{}, err
+			}
+			if indexOfObjectID(current.ProjectIDs, project.ID) < 0 {
+				return models.CollectionPage{}, ErrCollectionFull
+			}
+		}
+	}
+
+	return s.GetCollectionPage(ctx, collection.ID.Hex(), ownerID)
+}
+
+// RemoveProject удаляет проект из подборки и возвращает подборку с проектами
+func (s *CollectionService) RemoveProject(ctx context.Context, collection models.Collection, projectID string) (models.CollectionPage, error) {
+	projectObjectID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return models.CollectionPage{}, ErrProjectUnavailable
+	}
+
+	if err := s.collectionRepo.RemoveProject(ctx, collection.ID, projectObjectID); err != nil {
+		return models.CollectionPage{}, err
+	}
+
+	return s.GetCollectionPage(ctx, collection.ID.Hex(), collection.UserID.Hex())
+}
+
+// ReorderProjects задает порядок проектов подборки; список должен содержать все ее проекты ровно по одному разу
+func (s *CollectionService) ReorderProjects(ctx context.Context, collection models.Collection, projectIDs []string) (models.CollectionPage, error) {
+	if len(projectIDs) != len(collection.ProjectIDs) {
+		return models.CollectionPage{}, ErrInvalidCollectionOrder
+	}
+
+	order := make([]primitive.ObjectID, 0, len(projectIDs))
+	seen := make(map[primitive.ObjectID]bool, len(projectIDs))
+	for _, id := range projectIDs {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil || seen[objectID] || indexOfObjectID(collection.ProjectIDs, objectID) < 0 {
+			return models.CollectionPage{}, ErrInvalidCollectionOrder
+		}
+		seen[objectID] = true
+		order = append(order, objectID)
+	}
+
+	updated, err := s.collectionRepo.SetProjectOrder(ctx, collection.ID, collection.ProjectIDs, order)
+	if err != nil {
+		return models.CollectionPage{}, err
+	}
+	if !updated {
+		// Проекты подборки изменились после ее загрузки
+		return models.CollectionPage{}, ErrInvalidCollectionOrder
+	}
+
+	return s.GetCollectionPage(ctx, collection.ID.Hex(), collection.UserID.Hex())
+}
+
+// collectionPage загружает проекты подборки в ее порядке
+func (s *CollectionService) collectionPage(ctx context.Context, collection models.Collection, viewerID string) (models.CollectionPage, error) {
+	page := models.CollectionPage{Collection: collection, Projects: []models.Project{}}
+	if len(collection.ProjectIDs) == 0 {
+		return page, nil
+	}
+
+	projects, err := s.projectRepo.FindByIDs(ctx, collection.ProjectIDs)
+	if err != nil {
+		return page, err
+	}
+	byID := make(map[primitive.ObjectID]models.Project, len(projects))
+	for _, project := range projects {
+		byID[project.ID] = project
+	}
+
+	for _, id := range collection.ProjectIDs {
+		if project, ok := byID[id]; ok && project.IsVisibleTo(viewerID) {
+			page.Projects = append(page.Projects, project)
+		}
+	}
+	return page, nil
+}
+
+// prepareCollection нормализует и проверяет название, описание и видимость подборки
+func prepareCollection(collection *models.Collection) error {
+	collection.Name = strings.TrimSpace(collection.Name)
+	collection.Description = strings.TrimSpace(collection.Description)
+	if collection.Visibility == "" {
+		collection.Visibility = models.CollectionPublic
+	}
+
+	switch {
+	case collection.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidCollection)
+	case utf8.RuneCountInString(collection.Name) > MaxCollectionNameLength:
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidCollection, MaxCollectionNameLength)
+	case utf8.RuneCountInString(collection.Description) > MaxCollectionDescriptionLength:
+		return fmt.Errorf("%w: description must be at most %d characters", ErrInvalidCollection, MaxCollectionDescriptionLength)
+	case collection.Visibility != models.CollectionPublic && collection.Visibility != models.CollectionPrivate:
+		return fmt.Errorf("%w: visibility must be public or private", ErrInvalidCollection)
+	}
+	return nil
+}
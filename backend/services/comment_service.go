@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// MaxCommentLength максимальная длина комментария в символах
+	MaxCommentLength = 5000
+	// DefaultCommentPageSize размер страницы комментариев по умолчанию
+	DefaultCommentPageSize = 20
+	// MaxCommentPageSize максимальный размер страницы комментариев
+	MaxCommentPageSize = 100
+)
+
+var (
+	// ErrCommentNotFound возвращается, если комментария нет у проекта
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrCommentForbidden возвращается, если пользователь не может изменить или удалить комментарий
+	ErrCommentForbidden = errors.New("you can only modify your own comments")
+	// ErrInvalidComment возвращается для пустого или слишком длинного комментария
+	ErrInvalidComment = errors.New("comment must be between 1 and 5000 characters")
+	// ErrInvalidCursor возвращается для некорректного курсора страницы
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// CommentService представляет сервис комментариев к проектам
+type CommentService struct {
+	commentRepo *repositories.CommentRepository
+	projectRepo *repositories.ProjectRepository
+	userRepo    *repositories.UserRepository
+}
+
+// NewCommentService создает новый сервис комментариев
+func NewCommentService(commentRepo *repositories.CommentRepository, projectRepo *repositories.ProjectRepository, userRepo *repositories.UserRepository) *CommentService {
+	return &CommentService{
+		commentRepo: commentRepo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// AddComment добавляет комментарий к проекту. Если задан parentID, комментарий становится ответом;
+// ответ на ответ прикрепляется к исходному комментарию верхнего уровня.
+func (s *CommentService) AddComment(ctx context.Context, projectID, authorID, content, parentID string) (models.Comment, error) {
+	content, contentHTML, err := prepareComment(content)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return models.Comment{}, err
+	}
+	if !project.IsVisibleTo(authorID) {
+		return models.Comment{}, mongo.ErrNoDocuments
+	}
+
+	// Получить информацию об авторе для добавления в комментарий
+	author, err := s.userRepo.FindByID(ctx, authorID)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	comment := models.Comment{
+		ProjectID:    project.ID,
+		AuthorID:     author.ID,
+		AuthorName:   author.Name,
+		AuthorAvatar: author.Avatar,
+		Content:      content,
+		ContentHTML:  contentHTML,
+	}
+
+	if parentID != "" {
+		parent, err := s.findProjectComment(ctx, project.ID, parentID)
+		if err != nil {
+			return models.Comment{}, err
+		}
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+		} else {
+			comment.ParentID = &parent.ID
+		}
+	}
+
+	comment, err = s.commentRepo.Create(ctx, comment)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	if comment.ParentID != nil {
+		if err := s.commentRepo.IncrementReplyCount(ctx, *comment.ParentID, 1); err != nil {
+			return models.Comment{}, err
+		}
+	}
+	if err := s.projectRepo.IncrementCommentCount(ctx, project.ID, 1); err != nil {
+		return models.Comment{}, err
+	}
+
+	return comment, nil
+}
+
+// GetComments возвращает страницу комментариев верхнего уровня проекта; комментарии к черновику
+// или архивному проекту видят только его участники
+func (s *CommentService) GetComments(ctx context.Context, projectID, viewerID, cursor string, limit int) (models.CommentPage, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return models.CommentPage{}, err
+	}
+	if !project.IsVisibleTo(viewerID) {
+		return models.CommentPage{}, mongo.ErrNoDocuments
+	}
+
+	return s.page(ctx, project.ID, nil, cursor, limit)
+}
+
+// GetReplies возвращает страницу ответов на комментарий с теми же ограничениями, что и GetComments
+func (s *CommentService) GetReplies(ctx context.Context, projectID, viewerID, commentID, cursor string, limit int) (models.CommentPage, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return models.CommentPage{}, err
+	}
+	if !project.IsVisibleTo(viewerID) {
+		return models.CommentPage{}, mongo.ErrNoDocuments
+	}
+
+	parent, err := s.findProjectComment(ctx, project.ID, commentID)
+	if err != nil {
+		return models.CommentPage{}, err
+	}
+
+	return s.page(ctx, project.ID, &parent.ID, cursor, limit)
+}
+
+// UpdateComment изменяет текст комментария; доступно только автору
+func (s *CommentService) UpdateComment(ctx context.Context, projectID, commentID, userID, content string) (models.Comment, error) {
+	content, contentHTML, err := prepareComment(content)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	projectObjectID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return models.Comment{}, ErrCommentNotFound
+	}
+
+	comment, err := s.findProjectComment(ctx, projectObjectID, commentID)
+	if err != nil {
+		return models.Comment{}, err
+	}
+	if comment.AuthorID.Hex() != userID {
+		return models.Comment{}, ErrCommentForbidden
+	}
+
+	if err := s.commentRepo.UpdateContent(ctx, comment.ID, content, contentHTML); err != nil {
+		return models.Comment{}, err
+	}
+
+	return s.commentRepo.FindByID(ctx, commentID)
+}
+
+// DeleteComment удаляет комментарий вместе с ответами на него.
+// Удалить комментарий может его автор или владелец проекта.
+func (s *CommentService) DeleteComment(ctx context.Context, projectID, commentID, userID string) error {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	comment, err := s.findProjectComment(ctx, project.ID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID.Hex() != userID && project.UserID.Hex() != userID {
+		return ErrCommentForbidden
+	}
+
+	deleted, err := s.commentRepo.Delete(ctx, comment.ID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrCommentNotFound
+	}
+
+	if comment.ParentID != nil {
+		if err := s.commentRepo.IncrementReplyCount(ctx, *comment.ParentID, -1); err != nil {
+			return err
+		}
+	}
+	return s.projectRepo.IncrementCommentCount(ctx, project.ID, -int(deleted))
+}
+
+// page возвращает страницу комментариев; курсор - ID последнего комментария предыдущей страницы
+func (s *CommentService) page(ctx context.Context, projectID primitive.ObjectID, parentID *primitive.ObjectID, cursor string, limit int) (models.CommentPage, error) {
+	if limit <= 0 {
+		limit = DefaultCommentPageSize
+	}
+	if limit > MaxCommentPageSize {
+		limit = MaxCommentPageSize
+	}
+
+	var after *primitive.ObjectID
+	if cursor != "" {
+		objectID, err := primitive.ObjectIDFromHex(cursor)
+		if err != nil {
+			return models.CommentPage{}, ErrInvalidCursor
+		}
+		after = &objectID
+	}
+
+	// Запрашиваем на один комментарий больше, чтобы узнать, есть ли следующая страница
+	comments, err := s.commentRepo.FindPage(ctx, projectID, parentID, after, int64(limit+1))
+	if err != nil {
+		return models.CommentPage{}, err
+	}
+
+	page := models.CommentPage{Comments: comments}
+	if len(comments) > limit {
+		page.Comments = comments[:limit]
+		page.NextCursor = page.Comments[limit-1].ID.Hex()
+	}
+	if page.Comments == nil {
+		page.Comments = []models.Comment{}
+	}
+
+	return page, nil
+}
+
+// findProjectComment находит комментарий, принадлежащий проекту
+func (s *CommentService) findProjectComment(ctx context.Context, projectID primitive.ObjectID, commentID string) (models.Comment, error) {
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+			return models.Comment{}, ErrCommentNotFound
+		}
+		return models.Comment{}, err
+	}
+	if comment.ProjectID != projectID {
+		return models.Comment{}, ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// prepareComment проверяет длину комментария и преобразует Markdown в безопасный HTML
+func prepareComment(content string) (string, string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" || utf8.RuneCountInString(content) > MaxCommentLength {
+		return "", "", ErrInvalidComment
+	}
+
+	contentHTML, err := RenderMarkdown(content)
+	if err != nil {
+		return "", "", err
+	}
+	return content, contentHTML, nil
+}
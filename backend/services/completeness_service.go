@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+)
+
+// CompletenessConfig задает веса и пороги пунктов оценки заполненности профиля
+type CompletenessConfig struct {
+	BioWeight     int
+	AvatarWeight  int
+	ProjectWeight int
+	SocialWeight  int
+	SkillsWeight  int
+	ReviewsWeight int
+
+	MinProjects    int
+	MinSocialLinks int
+	MinSkills      int
+	MinReviews     int
+}
+
+// DefaultCompletenessConfig возвращает конфигурацию оценки заполненности по умолчанию
+func DefaultCompletenessConfig() CompletenessConfig {
+	return CompletenessConfig{
+		BioWeight:     20,
+		AvatarWeight:  15,
+		ProjectWeight: 25,
+		SocialWeight:  15,
+		SkillsWeight:  15,
+		ReviewsWeight: 10,
+
+		MinProjects:    3,
+		MinSocialLinks: 2,
+		MinSkills:      5,
+		MinReviews:     1,
+	}
+}
+
+// LoadCompletenessConfig возвращает конфигурацию по умолчанию, в которой веса и пороги заменены
+// значениями переменных окружения (COMPLETENESS_BIO_WEIGHT, COMPLETENESS_MIN_PROJECTS и т.д.).
+// getenv - функция чтения переменной, обычно os.Getenv; незаданные переменные не меняют значение по умолчанию.
+func LoadCompletenessConfig(getenv func(string) string) (CompletenessConfig, error) {
+	config := DefaultCompletenessConfig()
+	fields := []struct {
+		name  string
+		value *int
+	}{
+		{"COMPLETENESS_BIO_WEIGHT", &config.BioWeight},
+		{"COMPLETENESS_AVATAR_WEIGHT", &config.AvatarWeight},
+		{"COMPLETENESS_PROJECT_WEIGHT", &config.ProjectWeight},
+		{"COMPLETENESS_SOCIAL_WEIGHT", &config.SocialWeight},
+		{"COMPLETENESS_SKILLS_WEIGHT", &config.SkillsWeight},
+		{"COMPLETENESS_REVIEWS_WEIGHT", &config.ReviewsWeight},
+		{"COMPLETENESS_MIN_PROJECTS", &config.MinProjects},
+		{"COMPLETENESS_MIN_SOCIAL_LINKS", &config.MinSocialLinks},
+		{"COMPLETENESS_MIN_SKILLS", &config.MinSkills},
+		{"COMPLETENESS_MIN_REVIEWS", &config.MinReviews},
+	}
+
+	for _, field := range fields {
+		raw := strings.TrimSpace(getenv(field.name))
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return CompletenessConfig{}, fmt.Errorf("%s must be a non-negative integer, got %q", field.name, raw)
+		}
+		*field.value = value
+	}
+
+	// Пункт с нулевым весом не учитывается, но хотя бы один пункт должен остаться
+	if config.BioWeight+config.AvatarWeight+config.ProjectWeight+config.SocialWeight+config.SkillsWeight+config.ReviewsWeight == 0 {
+		return CompletenessConfig{}, errors.New("at least one completeness weight must be positive")
+	}
+	return config, nil
+}
+
+// CompletenessService вычисляет оценку заполненности профилей
+type CompletenessService struct {
+	config      CompletenessConfig
+	projectRepo *repositories.ProjectRepository
+	reviewRepo  *repositories.ReviewRepository
+}
+
+// NewCompletenessService создает новый сервис оценки заполненности профилей
+func NewCompletenessService(config CompletenessConfig, projectRepo *repositories.ProjectRepository, reviewRepo *repositories.ReviewRepository) *CompletenessService {
+	return &CompletenessService{
+		config:      config,
+		projectRepo: projectRepo,
+		reviewRepo:  reviewRepo,
+	}
+}
+
+// GetCompleteness вычисляет оценку заполненности профиля пользователя
+func (s *CompletenessService) GetCompleteness(ctx context.Context, user models.User) (models.ProfileCompleteness, error) {
+	projectCount, err := s.projectRepo.CountByUserID(ctx, user.ID.Hex())
+	if err != nil {
+		return models.ProfileCompleteness{}, err
+	}
+
+	reviewCount, err := s.reviewRepo.CountByUserID(ctx, user.ID.Hex())
+	if err != nil {
+		return models.ProfileCompleteness{}, err
+	}
+
+	return s.evaluate(user, int(projectCount), int(reviewCount)), nil
+}
+
+// SortByCompleteness сортирует пользователей по убыванию заполненности профиля
+func (s *CompletenessService) SortByCompleteness(ctx context.Context, users []models.User) error {
+	projectCounts, err := s.projectRepo.CountPerUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	reviewCounts, err := s.reviewRepo.CountPerUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	scores := make(map[string]int, len(users))
+	for _, user := range users {
+		scores[user.ID.Hex()] = s.evaluate(user, projectCounts[user.ID], reviewCounts[user.ID]).Score
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return scores[users[i].ID.Hex()] > scores[users[j].ID.Hex()]
+	})
+	return nil
+}
+
+// evaluate вычисляет оценку по данным профиля и количеству проектов и отзывов
+func (s *CompletenessService) evaluate(user models.User, projectCount, reviewCount int) models.ProfileCompleteness {
+	cfg := s.config
+	socialLinks := countSocialLinks(user.Social)
+
+	checks := []struct {
+		done bool
+		item models.CompletenessItem
+	}{
+		{
+			done: strings.TrimSpace(user.Bio) != "",
+			item: models.CompletenessItem{Key: "bio", Weight: cfg.BioWeight,
+				Suggestion: "Write a short bio about yourself and your experience"},
+		},
+		{
+			done: user.Avatar != "",
+			item: models.CompletenessItem{Key: "avatar", Weight: cfg.AvatarWeight,
+				Suggestion: "Upload a profile photo"},
+		},
+		{
+			done: projectCount >= cfg.MinProjects,
+			item: models.CompletenessItem{Key: "projects", Weight: cfg.ProjectWeight,
+				Suggestion: fmt.Sprintf("Add at least %d projects (you have %d)", cfg.MinProjects, projectCount)},
+		},
+		{
+			done: socialLinks >= cfg.MinSocialLinks,
+			item: models.CompletenessItem{Key: "social", Weight: cfg.SocialWeight,
+				Suggestion: fmt.Sprintf("Link at least %d social profiles (you have %d)", cfg.MinSocialLinks, socialLinks)},
+		},
+		{
+			done: len(user.Skills) >= cfg.MinSkills,
+			item: models.CompletenessItem{Key: "skills", Weight: cfg.SkillsWeight,
+				Suggestion: fmt.Sprintf("List at least %d skills (you have %d)", cfg.MinSkills, len(user.Skills))},
+		},
+		{
+			done: reviewCount >= cfg.MinReviews,
+			item: models.CompletenessItem{Key: "reviews", Weight: cfg.ReviewsWeight,
+				Suggestion: fmt.Sprintf("Ask colleagues for at least %d reviews (you have %d)", cfg.MinReviews, reviewCount)},
+		},
+	}
+
+	result := models.ProfileCompleteness{Missing: []models.CompletenessItem{}}
+	total, earned := 0, 0
+	for _, check := range checks {
+		if check.item.Weight <= 0 {
+			continue
+		}
+		total += check.item.Weight
+		if check.done {
+			earned += check.item.Weight
+		} else {
+			result.Missing = append(result.Missing, check.item)
+		}
+	}
+
+	if total > 0 {
+		result.Score = earned * 100 / total
+	}
+
+	// Самые весомые пункты показываем первыми
+	sort.SliceStable(result.Missing, func(i, j int) bool {
+		return result.Missing[i].Weight > result.Missing[j].Weight
+	})
+
+	return result
+}
+
+// countSocialLinks возвращает количество заполненных социальных ссылок
+func countSocialLinks(social models.Social) int {
+	count := 0
+	for _, link := range []string{social.GitHub, social.Twitter, social.LinkedIn, social.Website} {
+		if strings.TrimSpace(link) != "" {
+			count++
+		}
+	}
+	return count
+}
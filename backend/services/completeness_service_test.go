@@ -0,0 +1,48 @@
+package services
+
+import "testing"
+
+func TestLoadCompletenessConfig(t *testing.T) {
+	env := func(values map[string]string) func(string) string {
+		return func(name string) string { return values[name] }
+	}
+
+	config, err := LoadCompletenessConfig(env(nil))
+	if err != nil || config != DefaultCompletenessConfig() {
+		t.Fatalf("empty environment: config = %+v, err = %v, want defaults", config, err)
+	}
+
+	config, err = LoadCompletenessConfig(env(map[string]string{
+		"COMPLETENESS_REVIEWS_WEIGHT": "0",
+		"COMPLETENESS_BIO_WEIGHT":     " 30 ",
+		"COMPLETENESS_MIN_PROJECTS":   "1",
+	}))
+	if err != nil {
+		t.Fatalf("LoadCompletenessConfig: %v", err)
+	}
+	want := DefaultCompletenessConfig()
+	want.ReviewsWeight = 0
+	want.BioWeight = 30
+	want.MinProjects = 1
+	if config != want {
+		t.Fatalf("config = %+v, want %+v", config, want)
+	}
+
+	invalid := []map[string]string{
+		{"COMPLETENESS_AVATAR_WEIGHT": "ten"},
+		{"COMPLETENESS_MIN_SKILLS": "-1"},
+		{
+			"COMPLETENESS_BIO_WEIGHT":     "0",
+			"COMPLETENESS_AVATAR_WEIGHT":  "0",
+			"COMPLETENESS_PROJECT_WEIGHT": "0",
+			"COMPLETENESS_SOCIAL_WEIGHT":  "0",
+			"COMPLETENESS_SKILLS_WEIGHT":  "0",
+			"COMPLETENESS_REVIEWS_WEIGHT": "0",
+		},
+	}
+	for _, values := range invalid {
+		if _, err := LoadCompletenessConfig(env(values)); err == nil {
+			t.Errorf("%v: expected an error", values)
+		}
+	}
+}
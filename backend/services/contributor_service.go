@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrInvalidRole возвращается для роли, которую нельзя назначить участнику
+	ErrInvalidRole = errors.New("role must be maintainer or contributor")
+	// ErrAlreadyContributor возвращается при приглашении владельца или существующего участника
+	ErrAlreadyContributor = errors.New("user is already a member of the project")
+	// ErrContributorNotFound возвращается, если пользователь не участник и не приглашен
+	ErrContributorNotFound = errors.New("contributor not found")
+	// ErrInvitationNotFound возвращается, если у пользователя нет приглашения в проект
+	ErrInvitationNotFound = errors.New("invitation not found")
+)
+
+// ContributorService управляет участниками проектов и приглашениями
+type ContributorService struct {
+	projectRepo *repositories.ProjectRepository
+	userRepo    *repositories.UserRepository
+}
+
+// NewContributorService создает новый сервис участников проектов
+func NewContributorService(projectRepo *repositories.ProjectRepository, userRepo *repositories.UserRepository) *ContributorService {
+	return &ContributorService{
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// Invite приглашает пользователя в проект с указанной ролью
+func (s *ContributorService) Invite(ctx context.Context, projectID, inviterID, userID, role string) (models.Project, error) {
+	if role != models.RoleMaintainer && role != models.RoleContributor {
+		return models.Project{}, ErrInvalidRole
+	}
+
+	inviter, err := primitive.ObjectIDFromHex(inviterID)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	// Получить информацию о пользователе для добавления в проект
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	added, err := s.projectRepo.AddContributor(ctx, projectID, models.Contributor{
+		UserID:     user.ID,
+		UserName:   user.Name,
+		UserAvatar: user.Avatar,
+		Role:       role,
+		Status:     models.ContributorInvited,
+		InvitedBy:  inviter,
+		InvitedAt:  time.Now(),
+	})
+	if err != nil {
+		return models.Project{}, err
+	}
+	if !added {
+		return models.Project{}, ErrAlreadyContributor
+	}
+
+	return s.projectRepo.FindByID(ctx, projectID)
+}
+
+// AcceptInvitation принимает приглашение пользователя в проект
+func (s *ContributorService) AcceptInvitation(ctx context.Context, projectID, userID string) (models.Project, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	accepted, err := s.projectRepo.AcceptInvitation(ctx, projectID, userObjectID, time.Now())
+	if err != nil {
+		return models.Project{}, err
+	}
+	if !accepted {
+		return models.Project{}, ErrInvitationNotFound
+	}
+
+	return s.projectRepo.FindByID(ctx, projectID)
+}
+
+// UpdateRole изменяет роль участника проекта
+func (s *ContributorService) UpdateRole(ctx context.Context, projectID, userID, role string) (models.Project, error) {
+	if role != models.RoleMaintainer && role != models.RoleContributor {
+		return models.Project{}, ErrInvalidRole
+	}
+
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return models.Project{}, ErrContributorNotFound
+	}
+
+	updated, err := s.projectRepo.UpdateContributorRole(ctx, projectID, userObjectID, role)
+	if err != nil {
+		return models.Project{}, err
+	}
+	if !updated {
+		return models.Project{}, ErrContributorNotFound
+	}
+
+	return s.projectRepo.FindByID(ctx, projectID)
+}
+
+// RemoveContributor удаляет участника из проекта или отзывает приглашение
+func (s *ContributorService) RemoveContributor(ctx context.Context, projectID, userID string) (models.Project, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return models.Project{}, ErrContributorNotFound
+	}
+
+	removed, err := s.projectRepo.RemoveContributor(ctx, projectID, userObjectID)
+	if err != nil {
+		return models.Project{}, err
+	}
+	if !removed {
+		return models.Project{}, ErrContributorNotFound
+	}
+
+	return s.projectRepo.FindByID(ctx, projectID)
+}
+
+// GetInvitations возвращает проекты, приглашения в которые пользователь еще не принял
+func (s *ContributorService) GetInvitations(ctx context.Context, userID string) ([]models.Project, error) {
+	projects, err := s.projectRepo.FindInvitations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if projects == nil {
+		projects = []models.Project{}
+	}
+	return projects, nil
+}
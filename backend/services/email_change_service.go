@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"your-project/backend/mailer"
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// EmailChangeTokenTTL время жизни ссылки подтверждения нового email
+	EmailChangeTokenTTL = 24 * time.Hour
+	// EmailRevertTokenTTL время, в течение которого можно откатить смену email со старого адреса
+	EmailRevertTokenTTL = 7 * 24 * time.Hour
+)
+
+var (
+	// ErrInvalidEmail возвращается для некорректного адреса
+	ErrInvalidEmail = errors.New("invalid email address")
+	// ErrEmailTaken возвращается, если адрес уже используется другим пользователем
+	ErrEmailTaken = errors.New("email is already in use")
+	// ErrSameEmail возвращается, если новый адрес совпадает с текущим
+	ErrSameEmail = errors.New("new email is the same as the current one")
+	// ErrInvalidEmailToken возвращается для неизвестной или просроченной ссылки
+	ErrInvalidEmailToken = errors.New("invalid or expired link")
+	// ErrEmailChangeRequiresConfirmation возвращается при попытке сменить email через обновление профиля
+	ErrEmailChangeRequiresConfirmation = errors.New("email can only be changed via the change-email flow")
+)
+
+// EmailChangeService реализует смену email с подтверждением нового адреса
+// и возможностью отката со старого
+type EmailChangeService struct {
+	userRepo *repositories.UserRepository
+	mailer   mailer.Mailer
+	baseURL  string
+	now      func() time.Time
+}
+
+// NewEmailChangeService создает новый сервис смены email.
+// baseURL - внешний адрес API, используемый в ссылках из писем.
+func NewEmailChangeService(userRepo *repositories.UserRepository, mailer mailer.Mailer, baseURL string) *EmailChangeService {
+	return &EmailChangeService{
+		userRepo: userRepo,
+		mailer:   mailer,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		now:      time.Now,
+	}
+}
+
+// RequestEmailChange проверяет пароль и отправляет ссылку подтверждения на новый адрес.
+// Текущий email не меняется до подтверждения.
+func (s *EmailChangeService) RequestEmailChange(ctx context.Context, userID, newEmail, password string) error {
+	newEmail, err := normalizeEmail(newEmail)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return ErrInvalidCredentials
+	}
+
+	if strings.EqualFold(user.Email, newEmail) {
+		return ErrSameEmail
+	}
+	if err := s.ensureEmailAvailable(ctx, newEmail, userID); err != nil {
+		return err
+	}
+
+	token, tokenHash, err := newEmailToken()
+	if err != nil {
+		return err
+	}
+
+	// Сохраняем возможность отката предыдущей смены, если она еще действует
+	change := models.EmailChange{}
+	if user.EmailChange != nil {
+		change = *user.EmailChange
+	}
+	change.PendingEmail = newEmail
+	change.TokenHash = tokenHash
+	change.ExpiresAt = s.now().Add(EmailChangeTokenTTL)
+
+	if err := s.userRepo.SetEmailChange(ctx, userID, &change); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm that you want to use this address for your account:\n\n%s\n\nThe link expires in %d hours. If you did not request this change, ignore this email.\n",
+			user.Name, s.link("confirm", token), int(EmailChangeTokenTTL.Hours()),
+		),
+	})
+}
+
+// ConfirmEmailChange применяет смену email по ссылке из письма и уведомляет старый адрес
+func (s *EmailChangeService) ConfirmEmailChange(ctx context.Context, token string) (models.User, error) {
+	user, err := s.userRepo.FindByEmailChangeToken(ctx, hashEmailToken(token))
+	if err != nil || user.EmailChange == nil || s.now().After(user.EmailChange.ExpiresAt) {
+		return models.User{}, ErrInvalidEmailToken
+	}
+
+	newEmail := user.EmailChange.PendingEmail
+	oldEmail := user.Email
+
+	// Адрес мог быть занят, пока письмо ждало подтверждения
+	if err := s.ensureEmailAvailable(ctx, newEmail, user.ID.Hex()); err != nil {
+		return models.User{}, err
+	}
+
+	revertToken, revertHash, err := newEmailToken()
+	if err != nil {
+		return models.User{}, err
+	}
+
+	change := &models.EmailChange{
+		PreviousEmail:   oldEmail,
+		RevertTokenHash: revertHash,
+		RevertExpiresAt: s.now().Add(EmailRevertTokenTTL),
+	}
+	if err := s.userRepo.UpdateEmail(ctx, user.ID.Hex(), newEmail, change); err != nil {
+		if errors.Is(err, repositories.ErrEmailExists) {
+			return models.User{}, ErrEmailTaken
+		}
+		return models.User{}, err
+	}
+
+	// Email уже изменен, поэтому ошибка отправки уведомления не делает подтверждение неудачным
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      oldEmail,
+		Subject: "Your email address was changed",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nThe email address of your account was changed to %s.\n\nIf you did not make this change, restore your previous address using this link:\n\n%s\n\nThe link is valid for %d days.\n",
+			user.Name, newEmail, s.link("revert", revertToken), int(EmailRevertTokenTTL.Hours()/24),
+		),
+	})
+	if err != nil {
+		log.Printf("Failed to notify %s about email change of user %s: %v", oldEmail, user.ID.Hex(), err)
+	}
+
+	user.Email = newEmail
+	user.EmailChange = change
+	return user, nil
+}
+
+// RevertEmailChange возвращает предыдущий email по ссылке, отправленной на старый адрес
+func (s *EmailChangeService) RevertEmailChange(ctx context.Context, token string) (models.User, error) {
+	user, err := s.userRepo.FindByEmailRevertToken(ctx, hashEmailToken(token))
+	if err != nil || user.EmailChange == nil || s.now().After(user.EmailChange.RevertExpiresAt) {
+		return models.User{}, ErrInvalidEmailToken
+	}
+
+	previousEmail := user.EmailChange.PreviousEmail
+	if err := s.ensureEmailAvailable(ctx, previousEmail, user.ID.Hex()); err != nil {
+		return models.User{}, err
+	}
+
+	// Откат также отменяет ожидающую подтверждения смену
+	if err := s.userRepo.UpdateEmail(ctx, user.ID.Hex(), previousEmail, nil); err != nil {
+		if errors.Is(err, repositories.ErrEmailExists) {
+			return models.User{}, ErrEmailTaken
+		}
+		return models.User{}, err
+	}
+
+	user.Email = previousEmail
+	user.EmailChange = nil
+	return user, nil
+}
+
+// ensureEmailAvailable проверяет, что адрес не используется другим пользователем
+func (s *EmailChangeService) ensureEmailAvailable(ctx context.Context, email, userID string) error {
+	for _, candidate := range []string{email, strings.ToLower(email)} {
+		existing, err := s.userRepo.FindByEmail(ctx, candidate)
+		if err == nil && existing.ID.Hex() != userID {
+			return ErrEmailTaken
+		}
+	}
+	return nil
+}
+
+// link формирует ссылку на действие со сменой email
+func (s *EmailChangeService) link(action, token string) string {
+	return s.baseURL + "/api/auth/email/" + action + "?token=" + url.QueryEscape(token)
+}
+
+// normalizeEmail проверяет адрес и приводит его к нижнему регистру
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return strings.ToLower(email), nil
+}
+
+// newEmailToken генерирует токен для ссылки и его хеш для хранения
+func newEmailToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(b)
+	return token, hashEmailToken(token), nil
+}
+
+// hashEmailToken возвращает хеш токена
+func hashEmailToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
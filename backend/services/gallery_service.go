@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxGalleryItems максимальное количество элементов в галерее проекта
+const MaxGalleryItems = 20
+
+var (
+	// ErrGalleryFull возвращается при попытке добавить элемент в заполненную галерею
+	ErrGalleryFull = errors.New("project gallery is full")
+	// ErrMediaNotFound возвращается, если элемента нет в галерее проекта
+	ErrMediaNotFound = errors.New("media item not found")
+	// ErrInvalidMedia возвращается для некорректного типа или ссылки элемента галереи
+	ErrInvalidMedia = errors.New("invalid media item")
+	// ErrInvalidMediaOrder возвращается, если новый порядок не содержит ровно все элементы галереи
+	ErrInvalidMediaOrder = errors.New("order must list every media item of the project exactly once")
+)
+
+// videoHosts сервисы, ссылки на видео с которых можно добавлять в галерею
+var videoHosts = map[string]bool{
+	"youtube.com":    true,
+	"youtu.be":       true,
+	"vimeo.com":      true,
+	"loom.com":       true,
+	"streamable.com": true,
+}
+
+// videoExtensions расширения файлов, которые считаются прямыми ссылками на видео
+var videoExtensions = map[string]bool{
+	".mp4":  true,
+	".webm": true,
+	".mov":  true,
+}
+
+// GalleryService управляет галереей изображений и видео проекта
+type GalleryService struct {
+	projectRepo  *repositories.ProjectRepository
+	imageService *ImageService
+}
+
+// NewGalleryService создает новый сервис галерей
+func NewGalleryService(projectRepo *repositories.ProjectRepository, imageService *ImageService) *GalleryService {
+	return &GalleryService{
+		projectRepo:  projectRepo,
+		imageService: imageService,
+	}
+}
+
+// AddImage загружает изображение и добавляет его в галерею проекта
+func (s *GalleryService) AddImage(ctx context.Context, projectID string, data []byte, caption, altText string) (models.MediaItem, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return models.MediaItem{}, err
+	}
+	if len(project.Gallery) >= MaxGalleryItems {
+		return models.MediaItem{}, ErrGalleryFull
+	}
+
+	stored, err := s.imageService.StoreImage(ctx, "projects/"+projectID+"/gallery", data, ProjectImageVariants)
+	if err != nil {
+		return models.MediaItem{}, err
+	}
+
+	item := models.MediaItem{
+		Type:       models.MediaTypeImage,
+		URL:        stored.URL,
+		Thumbnails: stored.Thumbnails,
+		Caption:    strings.TrimSpace(caption),
+		AltText:    strings.TrimSpace(altText),
+	}
+	return s.addItem(ctx, project, item)
+}
+
+// AddLink добавляет в галерею ссылку на внешнее изображение или короткое видео
+func (s *GalleryService) AddLink(ctx context.Context, projectID string, item models.MediaItem) (models.MediaItem, error) {
+	if err := validateMediaLink(item); err != nil {
+		return models.MediaItem{}, err
+	}
+
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return models.MediaItem{}, err
+	}
+
+	item = models.MediaItem{
+		Type:    item.Type,
+		URL:     strings.TrimSpace(item.URL),
+		Caption: strings.TrimSpace(item.Caption),
+		AltText: strings.TrimSpace(item.AltText),
+	}
+	return s.addItem(ctx, project, item)
+}
+
+// UpdateMedia изменяет подпись и альтернативный текст элемента галереи
+func (s *GalleryService) UpdateMedia(ctx context.Context, projectID, mediaID, caption, altText string) (models.Project, error) {
+	objectID, err := primitive.ObjectIDFromHex(mediaID)
+	if err != nil {
+		return models.Project{}, ErrMediaNotFound
+	}
+
+	found, err := s.projectRepo.UpdateMedia(ctx, projectID, objectID, strings.TrimSpace(caption), strings.TrimSpace(altText))
+	if err != nil {
+		return models.Project{}, err
+	}
+	if !found {
+		return models.Project{}, ErrMediaNotFound
+	}
+
+	return s.projectRepo.FindByID(ctx, projectID)
+}
+
+// ReorderMedia задает порядок элементов галереи; mediaIDs должен содержать все элементы
+func (s *GalleryService) ReorderMedia(ctx context.Context, projectID string, mediaIDs []string) (models.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return models.Project{}, err
+	}
+	if len(mediaIDs) != len(project.Gallery) {
+		return models.Project{}, ErrInvalidMediaOrder
+	}
+
+	byID := make(map[string]models.MediaItem, len(project.Gallery))
+	for _, item := range project.Gallery {
+		byID[item.ID.Hex()] = item
+	}
+
+	gallery := make([]models.MediaItem, 0, len(mediaIDs))
+	for i, id := range mediaIDs {
+		item, ok := byID[id]
+		if !ok {
+			return models.Project{}, ErrInvalidMediaOrder
+		}
+		delete(byID, id) // повтор одного и того же ID тоже ошибка
+		item.Order = i
+		gallery = append(gallery, item)
+	}
+
+	if err := s.projectRepo.ReplaceGallery(ctx, projectID, gallery); err != nil {
+		return models.Project{}, err
+	}
+
+	project.Gallery = gallery
+	return project, nil
+}
+
+// SetCover делает элемент галереи обложкой проекта
+func (s *GalleryService) SetCover(ctx context.Context, projectID, mediaID string) (models.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	for _, item := range project.Gallery {
+		if item.ID.Hex() != mediaID {
+			continue
+		}
+		if item.Type != models.MediaTypeImage {
+			return models.Project{}, ErrInvalidMedia
+		}
+		if err := s.projectRepo.SetCover(ctx, projectID, item); err != nil {
+			return models.Project{}, err
+		}
+		return s.projectRepo.FindByID(ctx, projectID)
+	}
+
+	return models.Project{}, ErrMediaNotFound
+}
+
+// RemoveMedia удаляет элемент из галереи проекта вместе с загруженными файлами изображения
+func (s *GalleryService) RemoveMedia(ctx context.Context, projectID, mediaID string) error {
+	objectID, err := primitive.ObjectIDFromHex(mediaID)
+	if err != nil {
+		return ErrMediaNotFound
+	}
+
+	item, found, err := s.projectRepo.RemoveMedia(ctx, projectID, objectID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrMediaNotFound
+	}
+
+	// Элемент уже удален из проекта, поэтому ошибка хранилища только записывается в лог
+	if err := s.imageService.DeleteImage(ctx, StoredImage{URL: item.URL, Thumbnails: item.Thumbnails}); err != nil {
+		log.Printf("Failed to delete files of media item %s: %v", item.ID.Hex(), err)
+	}
+	return nil
+}
+
+// addItem добавляет элемент в конец галереи
+func (s *GalleryService) addItem(ctx context.Context, project models.Project, item models.MediaItem) (models.MediaItem, error) {
+	item.ID = primitive.NewObjectID()
+	item.CreatedAt = time.Now()
+	for _, existing := range project.Gallery {
+		if existing.Order >= item.Order {
+			item.Order = existing.Order + 1
+		}
+	}
+
+	added, err := s.projectRepo.AddMedia(ctx, project.ID.Hex(), item, MaxGalleryItems)
+	if err != nil {
+		return models.MediaItem{}, err
+	}
+	if !added {
+		return models.MediaItem{}, ErrGalleryFull
+	}
+
+	return item, nil
+}
+
+// validateMediaLink проверяет тип и ссылку элемента галереи, добавляемого по URL
+func validateMediaLink(item models.MediaItem) error {
+	parsed, err := url.Parse(strings.TrimSpace(item.URL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidMedia
+	}
+
+	switch item.Type {
+	case models.MediaTypeImage:
+		return nil
+	case models.MediaTypeVideo:
+		host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+		host = strings.TrimPrefix(host, "m.")
+		if videoHosts[host] || videoExtensions[strings.ToLower(path.Ext(parsed.Path))] {
+			return nil
+		}
+	}
+	return ErrInvalidMedia
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"your-project/backend/github"
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxGitHubImportRepositories максимальное количество репозиториев в одном импорте
+const MaxGitHubImportRepositories = 100
+
+// ErrInvalidGitHubImport возвращается для пустого или слишком большого списка репозиториев
+var ErrInvalidGitHubImport = fmt.Errorf("select from 1 to %d repositories to import", MaxGitHubImportRepositories)
+
+// GitHubImportService импортирует публичные репозитории пользователя GitHub как проекты
+type GitHubImportService struct {
+	projectRepo    *repositories.ProjectRepository
+	projectService *ProjectService
+	client         github.Client
+}
+
+// NewGitHubImportService создает новый сервис импорта репозиториев
+func NewGitHubImportService(projectRepo *repositories.ProjectRepository, projectService *ProjectService, client github.Client) *GitHubImportService {
+	return &GitHubImportService{
+		projectRepo:    projectRepo,
+		projectService: projectService,
+		client:         client,
+	}
+}
+
+// ListRepositories возвращает публичные репозитории пользователя GitHub username; у репозиториев,
+// которые уже есть среди проектов пользователя userID, заполнен projectId
+func (s *GitHubImportService) ListRepositories(ctx context.Context, userID, username string) ([]models.GitHubImportCandidate, error) {
+	repositories, err := s.client.ListUserRepositories(ctx, strings.TrimSpace(username))
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.existingRepositories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]models.GitHubImportCandidate, 0, len(repositories))
+	for _, repository := range repositories {
+		candidate := models.GitHubImportCandidate{
+			Name:        repository.Name,
+			FullName:    repository.FullName,
+			Description: repository.Description,
+			URL:         repository.URL,
+			Homepage:    repository.Homepage,
+			Language:    repository.Language,
+			Topics:      repository.Topics,
+			Stars:       repository.Stars,
+			Forks:       repository.Forks,
+			Fork:        repository.Fork,
+			Archived:    repository.Archived,
+			PushedAt:    repository.PushedAt,
+		}
+		if projectID, ok := existing[repositoryKey(repository.URL)]; ok {
+			candidate.ProjectID = &projectID
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+// Import создает проекты пользователя userID из выбранных репозиториев пользователя GitHub username.
+// Данные репозиториев берутся из GitHub, а не из запроса. Репозитории, которые уже есть среди
+// проектов пользователя (по ссылке на GitHub), пропускаются. Ошибка создания одного проекта не отменяет
+// уже созданные: репозиторий попадает в пропущенные с причиной. status задает статус новых проектов.
+func (s *GitHubImportService) Import(ctx context.Context, userID, username string, names []string, status string) (models.GitHubImportResult, error) {
+	result := models.GitHubImportResult{
+		Created: []models.Project{},
+		Skipped: []models.GitHubImportSkip{},
+	}
+	if len(names) == 0 || len(names) > MaxGitHubImportRepositories {
+		return result, ErrInvalidGitHubImport
+	}
+	// Статус проверяется до обращения к GitHub; импортировать сразу в архив нельзя
+	if status != "" && status != models.ProjectDraft && status != models.ProjectPublished {
+		return result, fmt.Errorf("%w: imported projects must be draft or published", ErrInvalidProjectStatus)
+	}
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return result, err
+	}
+
+	repositories, err := s.client.ListUserRepositories(ctx, strings.TrimSpace(username))
+	if err != nil {
+		return result, err
+	}
+	byName := make(map[string]github.RepositorySummary, len(repositories))
+	for _, repository := range repositories {
+		byName[strings.ToLower(repository.Name)] = repository
+	}
+
+	existing, err := s.existingRepositories(ctx, userID)
+	if err != nil {
+		return result, err
+	}
+
+	for _, name := range names {
+		repository, ok := byName[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			result.Skipped = append(result.Skipped, models.GitHubImportSkip{Name: name, Reason: models.ImportSkipNotFound})
+			continue
+		}
+
+		// Повторы в запросе тоже попадают сюда, так как созданный проект добавляется в existing
+		key := repositoryKey(repository.URL)
+		if projectID, ok := existing[key]; ok {
+			result.Skipped = append(result.Skipped, models.GitHubImportSkip{
+				Name:      repository.Name,
+				Reason:    models.ImportSkipDuplicate,
+				ProjectID: &projectID,
+			})
+			continue
+		}
+
+		project, err := s.projectService.CreateProject(ctx, projectFromRepository(ownerID, repository, status))
+		if err != nil {
+			log.Printf("Failed to import GitHub repository %s for user %s: %v", repository.FullName, userID, err)
+			result.Skipped = append(result.Skipped, models.GitHubImportSkip{
+				Name:   repository.Name,
+				Reason: models.ImportSkipFailed,
+				Error:  err.Error(),
+			})
+			continue
+		}
+		existing[key] = project.ID
+		result.Created = append(result.Created, project)
+	}
+
+	return result, nil
+}
+
+// existingRepositories возвращает репозитории проектов пользователя (включая проекты, в которых
+// он участвует): ключ repositoryKey и ID проекта
+func (s *GitHubImportService) existingRepositories(ctx context.Context, userID string) (map[string]primitive.ObjectID, error) {
+	projects, err := s.projectRepo.FindByMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]primitive.ObjectID, len(projects))
+	for _, project := range projects {
+		if key := repositoryKey(project.GitHubURL); key != "" {
+			existing[key] = project.ID
+		}
+	}
+	return existing, nil
+}
+
+// projectFromRepository заполняет проект по репозиторию: основной язык и темы становятся технологиями
+func projectFromRepository(ownerID primitive.ObjectID, repository github.RepositorySummary, status string) models.Project {
+	project := models.Project{
+		Title:        repository.Name,
+		Description:  repository.Description,
+		Technologies: []string{},
+		GitHubURL:    repository.URL,
+		Status:       status,
+		UserID:       ownerID,
+	}
+
+	homepage := strings.TrimSpace(repository.Homepage)
+	if strings.HasPrefix(homepage, "https://") || strings.HasPrefix(homepage, "http://") {
+		project.LiveURL = homepage
+	}
+
+	// Повторы и синонимы (golang для Go) не добавляются
+	seen := make(map[string]bool)
+	for _, technology := range append([]string{repository.Language}, repository.Topics...) {
+		key := normalizeTechnologyTerm(technology)
+		if canonical, ok := technologyAliases[key]; ok {
+			key = canonical
+		}
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		project.Technologies = append(project.Technologies, technology)
+	}
+	return project
+}
+
+// repositoryKey возвращает "owner/name" в нижнем регистре для ссылки на репозиторий GitHub
+// или пустую строку, если ссылка не указывает на репозиторий
+func repositoryKey(url string) string {
+	owner, name, err := github.ParseRepositoryURL(url)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(owner + "/" + name)
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"your-project/backend/github"
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// newTestImportService создает сервис импорта поверх имитации MongoDB
+func newTestImportService(mt *mtest.T, client github.Client) *GitHubImportService {
+	projectRepo := repositories.NewProjectRepository(mt.Client, "test")
+	projectService := NewProjectService(
+		projectRepo,
+		repositories.NewUserRepository(mt.Client, "test"),
+		repositories.NewCategoryRepository(mt.Client, "test"),
+		repositories.NewRevisionRepository(mt.Client, "test"),
+		repositories.NewCollectionRepository(mt.Client, "test"),
+		repositories.NewStarRepository(mt.Client, "test"),
+		repositories.NewCommentRepository(mt.Client, "test"),
+		nil,
+	)
+	return NewGitHubImportService(projectRepo, projectService, client)
+}
+
+// createProjectResponses ответы MongoDB на создание одного проекта в ProjectService.CreateProject:
+// автор, вставка проекта, автор версии, последняя версия (нет) и вставка версии
+func createProjectResponses(user bson.D) []bson.D {
+	return []bson.D{
+		findResponse("test.users", user),
+		mtest.CreateSuccessResponse(),
+		findResponse("test.users", user),
+		findResponse("test.project_revisions"),
+		mtest.CreateSuccessResponse(),
+	}
+}
+
+func TestImportSkipsDuplicatesAndUnknownRepositories(t *testing.T) {
+	newMockDB(t, "import", func(mt *mtest.T) {
+		user := models.User{ID: primitive.NewObjectID(), Name: "Octo Cat"}
+		existing := models.Project{
+			ID:        primitive.NewObjectID(),
+			UserID:    user.ID,
+			Title:     "Existing",
+			GitHubURL: "https://github.com/Octo/Existing.git",
+		}
+		client := &fakeGitHubClient{userRepositories: map[string][]github.RepositorySummary{
+			"octo": {
+				{
+					Name:        "new-app",
+					FullName:    "octo/new-app",
+					Description: "A new app",
+					URL:         "https://github.com/octo/new-app",
+					Homepage:    "https://new-app.dev",
+					Language:    "Go",
+					Topics:      []string{"golang", "cli", "CLI"},
+				},
+				{Name: "existing", FullName: "octo/existing", URL: "https://github.com/octo/existing"},
+				{Name: "broken", FullName: "octo/broken", URL: "https://github.com/octo/broken", Homepage: "javascript:alert(1)"},
+			},
+		}}
+		service := newTestImportService(mt, client)
+
+		userDocument := mockDocuments(mt.T, user)[0]
+		mt.AddMockResponses(findResponse("test.projects", mockDocuments(mt.T, existing)...))
+		mt.AddMockResponses(createProjectResponses(userDocument)...)
+		// Вставка последнего проекта завершается ошибкой
+		mt.AddMockResponses(
+			findResponse("test.users", userDocument),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 11600, Message: "interrupted at shutdown"}),
+		)
+
+		names := []string{"new-app", "NEW-APP", "existing", "ghost", "broken"}
+		result, err := service.Import(context.Background(), user.ID.Hex(), "octo", names, "")
+		if err != nil {
+			mt.Fatalf("Import: %v", err)
+		}
+		if want := []string{"octo"}; !reflect.DeepEqual(client.calls, want) {
+			mt.Fatalf("calls = %v, want %v", client.calls, want)
+		}
+
+		if len(result.Created) != 1 {
+			mt.Fatalf("created = %+v, want one project", result.Created)
+		}
+		created := result.Created[0]
+		if created.Title != "new-app" || created.Description != "A new app" || created.UserID != user.ID ||
+			created.UserName != user.Name || created.GitHubURL != "https://github.com/octo/new-app" ||
+			created.LiveURL != "https://new-app.dev" || created.Status != models.ProjectPublished {
+			mt.Fatalf("created = %+v", created)
+		}
+		// Язык и темы без повторов: golang - синоним Go
+		if want := []string{"Go", "cli"}; !reflect.DeepEqual(created.Technologies, want) {
+			mt.Fatalf("technologies = %v, want %v", created.Technologies, want)
+		}
+
+		want := []models.GitHubImportSkip{
+			{Name: "new-app", Reason: models.ImportSkipDuplicate, ProjectID: &created.ID},
+			{Name: "existing", Reason: models.ImportSkipDuplicate, ProjectID: &existing.ID},
+			{Name: "ghost", Reason: models.ImportSkipNotFound},
+			{Name: "broken", Reason: models.ImportSkipFailed},
+		}
+		if len(result.Skipped) != len(want) {
+			mt.Fatalf("skipped = %+v, want %+v", result.Skipped, want)
+		}
+		for i, skip := range result.Skipped {
+			if skip.Name != want[i].Name || skip.Reason != want[i].Reason || !reflect.DeepEqual(skip.ProjectID, want[i].ProjectID) {
+				mt.Errorf("skipped[%d] = %+v, want %+v", i, skip, want[i])
+			}
+		}
+		if result.Skipped[3].Error == "" {
+			mt.Error("failed skip has no error")
+		}
+	})
+}
+
+func TestImportValidatesRequest(t *testing.T) {
+	newMockDB(t, "validation", func(mt *mtest.T) {
+		client := &fakeGitHubClient{}
+		service := newTestImportService(mt, client)
+		userID := primitive.NewObjectID().Hex()
+
+		tooMany := make([]string, MaxGitHubImportRepositories+1)
+		tests := []struct {
+			name   string
+			names  []string
+			status string
+			want   error
+		}{
+			{"no repositories", nil, "", ErrInvalidGitHubImport},
+			{"too many repositories", tooMany, "", ErrInvalidGitHubImport},
+			{"archived status", []string{"app"}, models.ProjectArchived, ErrInvalidProjectStatus},
+		}
+		for _, tt := range tests {
+			result, err := service.Import(context.Background(), userID, "octo", tt.names, tt.status)
+			if !errors.Is(err, tt.want) {
+				mt.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+			}
+			if len(result.Created) != 0 || len(result.Skipped) != 0 {
+				mt.Errorf("%s: result = %+v", tt.name, result)
+			}
+		}
+		if len(client.calls) != 0 {
+			mt.Fatalf("calls = %v, want no GitHub requests", client.calls)
+		}
+
+		if _, err := service.Import(context.Background(), userID, "../octo", []string{"app"}, ""); !errors.Is(err, github.ErrInvalidUsername) {
+			mt.Fatalf("err = %v, want ErrInvalidUsername", err)
+		}
+	})
+}
+
+func TestListRepositoriesMarksImported(t *testing.T) {
+	newMockDB(t, "list", func(mt *mtest.T) {
+		userID := primitive.NewObjectID()
+		existing := models.Project{ID: primitive.NewObjectID(), UserID: userID, GitHubURL: "https://github.com/octo/old"}
+		client := &fakeGitHubClient{userRepositories: map[string][]github.RepositorySummary{
+			"octo": {
+				{Name: "old", URL: "https://github.com/octo/old", Topics: []string{}},
+				{Name: "fresh", URL: "https://github.com/octo/fresh", Topics: []string{}},
+			},
+		}}
+		service := newTestImportService(mt, client)
+		mt.AddMockResponses(findResponse("test.projects", mockDocuments(mt.T, existing)...))
+
+		candidates, err := service.ListRepositories(context.Background(), userID.Hex(), " octo ")
+		if err != nil {
+			mt.Fatalf("ListRepositories: %v", err)
+		}
+		if len(candidates) != 2 {
+			mt.Fatalf("candidates = %+v", candidates)
+		}
+		if candidates[0].ProjectID == nil || *candidates[0].ProjectID != existing.ID {
+			mt.Errorf("old: projectId = %v, want %v", candidates[0].ProjectID, existing.ID)
+		}
+		if candidates[1].ProjectID != nil {
+			mt.Errorf("fresh: projectId = %v, want nil", candidates[1].ProjectID)
+		}
+	})
+}
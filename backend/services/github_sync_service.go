@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"your-project/backend/github"
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+)
+
+const (
+	// GitHubSyncInterval период фонового обновления данных репозиториев
+	GitHubSyncInterval = time.Hour
+	// GitHubStaleAfter возраст данных репозитория, после которого они обновляются по расписанию
+	GitHubStaleAfter = 24 * time.Hour
+	// githubSyncBatchSize количество проектов, обновляемых за один запуск
+	githubSyncBatchSize = 50
+)
+
+// ErrNoGitHubURL возвращается при синхронизации проекта без ссылки на GitHub
+var ErrNoGitHubURL = errors.New("project has no github url")
+
+// GitHubSyncService синхронизирует метаданные репозиториев GitHub с проектами
+type GitHubSyncService struct {
+	projectRepo *repositories.ProjectRepository
+	client      github.Client
+	now         func() time.Time
+
+	mu sync.Mutex
+	// blockedUntil момент окончания ограничения частоты запросов GitHub
+	blockedUntil time.Time
+}
+
+// NewGitHubSyncService создает новый сервис синхронизации с GitHub
+func NewGitHubSyncService(projectRepo *repositories.ProjectRepository, client github.Client) *GitHubSyncService {
+	return &GitHubSyncService{
+		projectRepo: projectRepo,
+		client:      client,
+		now:         time.Now,
+	}
+}
+
+// SyncProject обновляет данные репозитория проекта по запросу
+func (s *GitHubSyncService) SyncProject(ctx context.Context, id string) (models.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Project{}, err
+	}
+	if project.GitHubURL == "" {
+		return models.Project{}, ErrNoGitHubURL
+	}
+
+	stats, err := s.syncProject(ctx, project)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	project.GitHub = &stats
+	return project, nil
+}
+
+// SyncStale обновляет проекты с устаревшими данными репозитория и возвращает количество обновленных.
+// При ограничении частоты запросов обновление прекращается до следующего запуска.
+func (s *GitHubSyncService) SyncStale(ctx context.Context) (int, error) {
+	projects, err := s.projectRepo.FindStaleGitHubProjects(ctx, s.now().Add(-GitHubStaleAfter), githubSyncBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return s.syncProjects(ctx, projects)
+}
+
+// syncProjects обновляет проекты по очереди и прекращает работу при ограничении частоты запросов
+func (s *GitHubSyncService) syncProjects(ctx context.Context, projects []models.Project) (int, error) {
+	synced := 0
+	for _, project := range projects {
+		_, err := s.syncProject(ctx, project)
+		var rateLimit *github.RateLimitError
+		switch {
+		case errors.As(err, &rateLimit):
+			return synced, err
+		case err != nil:
+			// Ошибка одного проекта не мешает обновлению остальных
+			log.Printf("Failed to sync GitHub data for project %s: %v", project.ID.Hex(), err)
+		default:
+			synced++
+		}
+	}
+
+	return synced, nil
+}
+
+// Run периодически обновляет устаревшие данные репозиториев, пока не будет отменен ctx
+func (s *GitHubSyncService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if synced, err := s.SyncStale(ctx); err != nil {
+			log.Printf("GitHub sync stopped: %v", err)
+		} else if synced > 0 {
+			log.Printf("GitHub sync updated %d projects", synced)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncProject получает данные репозитория из GitHub и сохраняет их в проекте
+func (s *GitHubSyncService) syncProject(ctx context.Context, project models.Project) (models.GitHubStats, error) {
+	if err := s.checkRateLimit(); err != nil {
+		return models.GitHubStats{}, err
+	}
+
+	id := project.ID.Hex()
+	owner, name, err := github.ParseRepositoryURL(project.GitHubURL)
+	if err != nil {
+		return models.GitHubStats{}, s.markFailed(ctx, id, err)
+	}
+
+	repo, err := s.client.GetRepository(ctx, owner, name)
+	var rateLimit *github.RateLimitError
+	switch {
+	case errors.As(err, &rateLimit):
+		s.mu.Lock()
+		s.blockedUntil = rateLimit.ResetAt
+		s.mu.Unlock()
+		return models.GitHubStats{}, err
+	case errors.Is(err, github.ErrRepositoryNotFound):
+		return models.GitHubStats{}, s.markFailed(ctx, id, err)
+	case err != nil:
+		return models.GitHubStats{}, err
+	}
+
+	stats := models.GitHubStats{
+		FullName:  repo.FullName,
+		Stars:     repo.Stars,
+		Forks:     repo.Forks,
+		Languages: repo.Languages,
+		Topics:    repo.Topics,
+		License:   repo.License,
+		PushedAt:  repo.PushedAt,
+		SyncedAt:  s.now(),
+	}
+	if err := s.projectRepo.UpdateGitHubStats(ctx, id, stats); err != nil {
+		return models.GitHubStats{}, err
+	}
+
+	return stats, nil
+}
+
+// checkRateLimit не дает обращаться к GitHub, пока действует ограничение частоты запросов
+func (s *GitHubSyncService) checkRateLimit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.now().Before(s.blockedUntil) {
+		return &github.RateLimitError{ResetAt: s.blockedUntil}
+	}
+	return nil
+}
+
+// markFailed сохраняет причину ошибки, чтобы проект не запрашивался повторно до следующего
+// планового обновления, и возвращает исходную ошибку
+func (s *GitHubSyncService) markFailed(ctx context.Context, id string, cause error) error {
+	if err := s.projectRepo.MarkGitHubSyncFailed(ctx, id, cause.Error(), s.now()); err != nil {
+		return err
+	}
+	return cause
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"your-project/backend/github"
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// fakeGitHubClient отвечает заранее заданными данными вместо GitHub API
+type fakeGitHubClient struct {
+	// repositories ответы GetRepository по "owner/name"; отсутствующие - ErrRepositoryNotFound
+	repositories map[string]github.Repository
+	// errors ошибки GetRepository по "owner/name"
+	errors map[string]error
+	// userRepositories ответы ListUserRepositories по имени пользователя
+	userRepositories map[string][]github.RepositorySummary
+	// calls запрошенные репозитории и пользователи в порядке запросов
+	calls []string
+}
+
+func (c *fakeGitHubClient) GetRepository(ctx context.Context, owner, name string) (github.Repository, error) {
+	key := owner + "/" + name
+	c.calls = append(c.calls, key)
+	if err, ok := c.errors[key]; ok {
+		return github.Repository{}, err
+	}
+	if repo, ok := c.repositories[key]; ok {
+		return repo, nil
+	}
+	return github.Repository{}, github.ErrRepositoryNotFound
+}
+
+func (c *fakeGitHubClient) ListUserRepositories(ctx context.Context, username string) ([]github.RepositorySummary, error) {
+	c.calls = append(c.calls, username)
+	if !github.ValidUsername(username) {
+		return nil, github.ErrInvalidUsername
+	}
+	repositories, ok := c.userRepositories[username]
+	if !ok {
+		return nil, github.ErrUserNotFound
+	}
+	return repositories, nil
+}
+
+func TestSyncStaleStopsOnRateLimit(t *testing.T) {
+	newMockDB(t, "sync stale", func(mt *mtest.T) {
+		now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		resetAt := now.Add(10 * time.Minute)
+		client := &fakeGitHubClient{
+			repositories: map[string]github.Repository{
+				"octo/first": {FullName: "octo/first", Stars: 5, Languages: []string{"Go"}, Topics: []string{}},
+				"octo/third": {FullName: "octo/third", Stars: 1, Languages: []string{}, Topics: []string{}},
+			},
+			errors: map[string]error{
+				"octo/second": &github.RateLimitError{ResetAt: resetAt},
+			},
+		}
+		service := NewGitHubSyncService(repositories.NewProjectRepository(mt.Client, "test"), client)
+		service.now = func() time.Time { return now }
+
+		projects := mockDocuments(mt.T,
+			models.Project{ID: primitive.NewObjectID(), Title: "first", GitHubURL: "https://github.com/octo/first"},
+			models.Project{ID: primitive.NewObjectID(), Title: "second", GitHubURL: "https://github.com/octo/second"},
+			models.Project{ID: primitive.NewObjectID(), Title: "third", GitHubURL: "https://github.com/octo/third"},
+		)
+		namespace := "test.projects"
+
+		// Первый проект обновляется, на втором GitHub ограничивает частоту, третий не запрашивается
+		mt.AddMockResponses(findResponse(namespace, projects...), mtest.CreateSuccessResponse())
+		synced, err := service.SyncStale(context.Background())
+		var rateLimit *github.RateLimitError
+		if !errors.As(err, &rateLimit) || !rateLimit.ResetAt.Equal(resetAt) {
+			mt.Fatalf("err = %v, want RateLimitError until %v", err, resetAt)
+		}
+		if synced != 1 {
+			mt.Fatalf("synced = %d, want 1", synced)
+		}
+		if want := []string{"octo/first", "octo/second"}; !reflect.DeepEqual(client.calls, want) {
+			mt.Fatalf("calls = %v, want %v", client.calls, want)
+		}
+
+		// Пока ограничение действует, GitHub не запрашивается
+		mt.AddMockResponses(findResponse(namespace, projects[1:]...))
+		synced, err = service.SyncStale(context.Background())
+		if !errors.As(err, &rateLimit) || synced != 0 {
+			mt.Fatalf("synced = %d, err = %v, want rate limit without requests", synced, err)
+		}
+		if len(client.calls) != 2 {
+			mt.Fatalf("calls = %v, want no new requests", client.calls)
+		}
+
+		// После сброса ограничения обновление продолжается
+		now = resetAt.Add(time.Second)
+		delete(client.errors, "octo/second")
+		client.repositories["octo/second"] = github.Repository{FullName: "octo/second", Languages: []string{}, Topics: []string{}}
+		mt.AddMockResponses(findResponse(namespace, projects[1:]...), mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+		synced, err = service.SyncStale(context.Background())
+		if err != nil || synced != 2 {
+			mt.Fatalf("synced = %d, err = %v, want 2 projects", synced, err)
+		}
+	})
+}
+
+func TestSyncStaleContinuesAfterProjectError(t *testing.T) {
+	newMockDB(t, "sync errors", func(mt *mtest.T) {
+		client := &fakeGitHubClient{
+			repositories: map[string]github.Repository{
+				"octo/ok": {FullName: "octo/ok", Languages: []string{}, Topics: []string{}},
+			},
+			errors: map[string]error{
+				"octo/broken": errors.New("github: 500 Internal Server Error"),
+			},
+		}
+		service := NewGitHubSyncService(repositories.NewProjectRepository(mt.Client, "test"), client)
+
+		projects := mockDocuments(mt.T,
+			// Репозитория нет: причина ошибки сохраняется в проекте
+			models.Project{ID: primitive.NewObjectID(), GitHubURL: "https://github.com/octo/missing"},
+			models.Project{ID: primitive.NewObjectID(), GitHubURL: "https://github.com/octo/broken"},
+			models.Project{ID: primitive.NewObjectID(), GitHubURL: "https://github.com/octo/ok"},
+		)
+		mt.AddMockResponses(findResponse("test.projects", projects...), mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		synced, err := service.SyncStale(context.Background())
+		if err != nil || synced != 1 {
+			mt.Fatalf("synced = %d, err = %v, want 1 project", synced, err)
+		}
+		if want := []string{"octo/missing", "octo/broken", "octo/ok"}; !reflect.DeepEqual(client.calls, want) {
+			mt.Fatalf("calls = %v, want %v", client.calls, want)
+		}
+	})
+}
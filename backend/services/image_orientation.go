@@ -0,0 +1,139 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+
+	"golang.org/x/image/draw"
+)
+
+// exifOrientationTag номер тега Orientation в EXIF (TIFF)
+const exifOrientationTag = 0x0112
+
+// jpegOrientation возвращает значение EXIF Orientation (1-8) из JPEG-файла.
+// Если метаданных нет или они повреждены, возвращается 1 (изображение не повернуто).
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			// Заполняющий байт перед маркером
+			i++
+			continue
+		case marker == 0xD9 || marker == 0xDA:
+			// Конец файла или начало данных изображения: метаданных дальше нет
+			return 1
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			// Маркеры без длины
+			i += 2
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation читает тег Orientation из первого каталога (IFD0) заголовка TIFF
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 1
+	}
+
+	offset := int64(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > int64(len(tiff)) {
+		return 1
+	}
+	ifd := tiff[offset:]
+	count := int(order.Uint16(ifd))
+	for n := 0; n < count; n++ {
+		entry := 2 + n*12
+		if entry+12 > len(ifd) {
+			return 1
+		}
+		if order.Uint16(ifd[entry:]) != exifOrientationTag {
+			continue
+		}
+		// Значение типа SHORT хранится прямо в записи каталога
+		if order.Uint16(ifd[entry+2:]) != 3 {
+			return 1
+		}
+		if value := int(order.Uint16(ifd[entry+8:])); value >= 1 && value <= 8 {
+			return value
+		}
+		return 1
+	}
+	return 1
+}
+
+// applyOrientation поворачивает и отражает изображение так, как его показывают с учетом EXIF Orientation
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	flat := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Copy(flat, image.Point{}, src, bounds, draw.Src, nil)
+
+	dstW, dstH := w, h
+	// Ориентации 5-8 меняют ширину и высоту местами
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // Отражение по горизонтали
+				dx, dy = w-1-x, y
+			case 3: // Поворот на 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // Отражение по вертикали
+				dx, dy = x, h-1-y
+			case 5: // Отражение относительно главной диагонали
+				dx, dy = y, x
+			case 6: // Поворот на 90° по часовой стрелке
+				dx, dy = h-1-y, x
+			case 7: // Отражение относительно побочной диагонали
+				dx, dy = h-1-y, w-1-x
+			case 8: // Поворот на 90° против часовой стрелки
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):][:4], flat.Pix[flat.PixOffset(x, y):][:4])
+		}
+	}
+	return dst
+}
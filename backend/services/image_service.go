@@ -0,0 +1,262 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	// Регистрируем декодеры поддерживаемых форматов
+	_ "image/gif"
+
+	_ "golang.org/x/image/webp"
+
+	"golang.org/x/image/draw"
+
+	"your-project/backend/storage"
+)
+
+const (
+	// MaxImageUploadSize максимальный размер загружаемого изображения (5 МБ)
+	MaxImageUploadSize = 5 << 20
+	// MaxImagePixels ограничивает размер декодируемого изображения, защищая от "декомпрессионных бомб"
+	MaxImagePixels = 40_000_000
+	// jpegQuality качество JPEG для сохраняемых вариантов
+	jpegQuality = 85
+)
+
+var (
+	// ErrUnsupportedImageType возвращается для файлов, не являющихся JPEG, PNG, GIF или WebP
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	// ErrImageTooLarge возвращается, если файл или его разрешение превышают лимиты
+	ErrImageTooLarge = errors.New("image is too large")
+)
+
+// allowedImageTypes типы содержимого, которые принимаются при загрузке (определяются по содержимому файла)
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// ImageVariant описывает один из сохраняемых размеров изображения
+type ImageVariant struct {
+	Name   string
+	Width  int
+	Height int
+	// Crop обрезает изображение по центру до точных размеров; иначе сохраняются пропорции
+	Crop bool
+}
+
+// AvatarVariants размеры, в которых сохраняются аватары пользователей
+var AvatarVariants = []ImageVariant{
+	{Name: "original", Width: 1024, Height: 1024},
+	{Name: "large", Width: 256, Height: 256, Crop: true},
+	{Name: "medium", Width: 128, Height: 128, Crop: true},
+	{Name: "small", Width: 64, Height: 64, Crop: true},
+}
+
+// ProjectImageVariants размеры, в которых сохраняются изображения проектов
+var ProjectImageVariants = []ImageVariant{
+	{Name: "original", Width: 2048, Height: 2048},
+	{Name: "large", Width: 1280, Height: 720},
+	{Name: "medium", Width: 640, Height: 360},
+	{Name: "small", Width: 320, Height: 180, Crop: true},
+}
+
+// StoredImage результат обработки загруженного изображения
+type StoredImage struct {
+	URL        string
+	Thumbnails map[string]string
+}
+
+// ImageService обрабатывает загруженные изображения и сохраняет их в хранилище
+type ImageService struct {
+	storage storage.Storage
+}
+
+// NewImageService создает новый сервис изображений
+func NewImageService(storage storage.Storage) *ImageService {
+	return &ImageService{storage}
+}
+
+// DetectImageType определяет тип изображения по содержимому и проверяет, что он поддерживается
+func DetectImageType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if !allowedImageTypes[contentType] {
+		return "", ErrUnsupportedImageType
+	}
+	return contentType, nil
+}
+
+// StoreImage проверяет, масштабирует и сохраняет изображение во всех вариантах.
+// Изображение всегда перекодируется, поэтому метаданные (EXIF, GPS и т.п.) в сохраненные файлы не попадают;
+// поворот из EXIF Orientation применяется к пикселям до перекодирования.
+func (s *ImageService) StoreImage(ctx context.Context, prefix string, data []byte, variants []ImageVariant) (StoredImage, error) {
+	if len(data) > MaxImageUploadSize {
+		return StoredImage{}, ErrImageTooLarge
+	}
+	contentType, err := DetectImageType(data)
+	if err != nil {
+		return StoredImage{}, err
+	}
+
+	// Проверяем разрешение до полного декодирования
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return StoredImage{}, ErrUnsupportedImageType
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return StoredImage{}, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return StoredImage{}, ErrUnsupportedImageType
+	}
+	if contentType == "image/jpeg" {
+		src = applyOrientation(src, jpegOrientation(data))
+	}
+
+	// PNG и GIF могут содержать прозрачность, поэтому сохраняем их как PNG
+	keepAlpha := contentType == "image/png" || contentType == "image/gif"
+
+	// Уникальный каталог для каждой загрузки, чтобы CDN и браузеры не отдавали старые версии
+	id, err := randomID()
+	if err != nil {
+		return StoredImage{}, err
+	}
+
+	result := StoredImage{Thumbnails: make(map[string]string)}
+	for _, variant := range variants {
+		resized := resizeImage(src, variant)
+
+		encoded, ext, mime, err := encodeImage(resized, keepAlpha)
+		if err != nil {
+			return StoredImage{}, err
+		}
+
+		url, err := s.storage.Put(ctx, prefix+"/"+id+"/"+variant.Name+ext, mime, encoded)
+		if err != nil {
+			return StoredImage{}, err
+		}
+
+		if variant.Name == "original" {
+			result.URL = url
+		} else {
+			result.Thumbnails[variant.Name] = url
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteImage удаляет из хранилища файлы изображения во всех вариантах.
+// Внешние ссылки (например, на видео) пропускаются; удаление продолжается после ошибки, возвращается первая.
+func (s *ImageService) DeleteImage(ctx context.Context, stored StoredImage) error {
+	urls := []string{stored.URL}
+	for _, url := range stored.Thumbnails {
+		urls = append(urls, url)
+	}
+
+	var firstErr error
+	for _, url := range urls {
+		key, ok := s.storage.KeyForURL(url)
+		if !ok {
+			continue
+		}
+		if err := s.storage.Delete(ctx, key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// resizeImage масштабирует изображение под вариант; изображения меньше варианта не увеличиваются
+func resizeImage(src image.Image, variant ImageVariant) image.Image {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	if variant.Crop {
+		// Вырезаем центральную область с пропорциями варианта
+		cropW, cropH := srcW, srcW*variant.Height/variant.Width
+		if cropH > srcH {
+			cropW, cropH = srcH*variant.Width/variant.Height, srcH
+		}
+		x0 := bounds.Min.X + (srcW-cropW)/2
+		y0 := bounds.Min.Y + (srcH-cropH)/2
+		srcRect := image.Rect(x0, y0, x0+cropW, y0+cropH)
+
+		dstW, dstH := variant.Width, variant.Height
+		if cropW < dstW {
+			dstW, dstH = cropW, cropH
+		}
+		return scale(src, srcRect, dstW, dstH)
+	}
+
+	if srcW <= variant.Width && srcH <= variant.Height {
+		return scale(src, bounds, srcW, srcH)
+	}
+
+	dstW, dstH := variant.Width, srcH*variant.Width/srcW
+	if dstH > variant.Height {
+		dstW, dstH = srcW*variant.Height/srcH, variant.Height
+	}
+	if dstW < 1 {
+		dstW = 1
+	}
+	if dstH < 1 {
+		dstH = 1
+	}
+	return scale(src, bounds, dstW, dstH)
+}
+
+// scale копирует область srcRect в новое изображение заданного размера
+func scale(src image.Image, srcRect image.Rectangle, width, height int) image.Image {
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if srcRect.Dx() == width && srcRect.Dy() == height {
+		draw.Copy(dst, image.Point{}, src, srcRect, draw.Src, nil)
+		return dst
+	}
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, srcRect, draw.Src, nil)
+	return dst
+}
+
+// encodeImage кодирует изображение в PNG (с прозрачностью) или JPEG
+func encodeImage(img image.Image, keepAlpha bool) ([]byte, string, string, error) {
+	var buf bytes.Buffer
+
+	if keepAlpha {
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", "", err
+		}
+		return buf.Bytes(), ".png", "image/png", nil
+	}
+
+	// JPEG не поддерживает прозрачность, поэтому подкладываем белый фон
+	bounds := img.Bounds()
+	flat := image.NewRGBA(bounds)
+	draw.Draw(flat, bounds, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, bounds, img, bounds.Min, draw.Over)
+
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, "", "", err
+	}
+	return buf.Bytes(), ".jpg", "image/jpeg", nil
+}
+
+// randomID генерирует случайный идентификатор для имен файлов
+func randomID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
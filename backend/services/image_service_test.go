@@ -0,0 +1,194 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"your-project/backend/storage"
+)
+
+// encodeTestPNG возвращает PNG заданного размера, залитый одним цветом
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDeleteImageRemovesAllVariants(t *testing.T) {
+	store := storage.NewLocalStorage(t.TempDir(), "/uploads")
+	service := NewImageService(store)
+	ctx := context.Background()
+
+	stored, err := service.StoreImage(ctx, "projects", encodeTestPNG(t, 64, 48), ProjectImageVariants)
+	if err != nil {
+		t.Fatalf("StoreImage: %v", err)
+	}
+	if len(stored.Thumbnails) != len(ProjectImageVariants)-1 {
+		t.Fatalf("thumbnails = %v", stored.Thumbnails)
+	}
+	// Внешние ссылки, например на видео, в хранилище не удаляются
+	stored.Thumbnails["video"] = "https://youtu.be/dQw4w9WgXcQ"
+
+	if err := service.DeleteImage(ctx, stored); err != nil {
+		t.Fatalf("DeleteImage: %v", err)
+	}
+	for _, url := range append([]string{stored.URL}, stored.Thumbnails["large"], stored.Thumbnails["small"]) {
+		key, ok := store.KeyForURL(url)
+		if !ok {
+			t.Fatalf("KeyForURL(%q) failed", url)
+		}
+		if _, err := store.Get(ctx, key); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("%s: err = %v, want ErrNotFound", key, err)
+		}
+	}
+}
+
+// encodeOrientedJPEG кодирует JPEG с сегментом EXIF, в котором записана ориентация orientation.
+// Левая половина изображения красная, правая - синяя.
+func encodeOrientedJPEG(t *testing.T, width, height, orientation int, order binary.ByteOrder) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= width/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+
+	// TIFF: заголовок, IFD0 с одной записью Orientation и нулевая ссылка на следующий каталог
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], exifOrientationTag)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], uint16(orientation))
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	data := encoded.Bytes()
+	result := append([]byte{}, data[:2]...)
+	result = append(result, app1...)
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	for orientation := 1; orientation <= 8; orientation++ {
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			if got := jpegOrientation(encodeOrientedJPEG(t, 8, 4, orientation, order)); got != orientation {
+				t.Errorf("%v orientation %d: got %d", order, orientation, got)
+			}
+		}
+	}
+
+	plain := encodeTestPNG(t, 4, 4)
+	if got := jpegOrientation(plain); got != 1 {
+		t.Errorf("PNG: got %d, want 1", got)
+	}
+	truncated := encodeOrientedJPEG(t, 8, 4, 6, binary.BigEndian)[:20]
+	if got := jpegOrientation(truncated); got != 1 {
+		t.Errorf("truncated: got %d, want 1", got)
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// Пиксели 3x2 пронумерованы по строкам: 0 1 2 / 3 4 5
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	for i := 0; i < 6; i++ {
+		src.Pix[i*4] = uint8(i)
+		src.Pix[i*4+3] = 255
+	}
+	tests := []struct {
+		orientation int
+		want        [][]uint8
+	}{
+		{1, [][]uint8{{0, 1, 2}, {3, 4, 5}}},
+		{2, [][]uint8{{2, 1, 0}, {5, 4, 3}}},
+		{3, [][]uint8{{5, 4, 3}, {2, 1, 0}}},
+		{4, [][]uint8{{3, 4, 5}, {0, 1, 2}}},
+		{5, [][]uint8{{0, 3}, {1, 4}, {2, 5}}},
+		{6, [][]uint8{{3, 0}, {4, 1}, {5, 2}}},
+		{7, [][]uint8{{5, 2}, {4, 1}, {3, 0}}},
+		{8, [][]uint8{{2, 5}, {1, 4}, {0, 3}}},
+	}
+	for _, tt := range tests {
+		got := applyOrientation(src, tt.orientation)
+		bounds := got.Bounds()
+		if bounds.Dy() != len(tt.want) || bounds.Dx() != len(tt.want[0]) {
+			t.Errorf("orientation %d: size %v", tt.orientation, bounds.Size())
+			continue
+		}
+		for y, row := range tt.want {
+			for x, want := range row {
+				if r, _, _, _ := got.At(x, y).RGBA(); uint8(r>>8) != want {
+					t.Errorf("orientation %d: pixel (%d, %d) = %d, want %d", tt.orientation, x, y, r>>8, want)
+				}
+			}
+		}
+	}
+}
+
+func TestStoreImageAppliesEXIFOrientation(t *testing.T) {
+	store := storage.NewLocalStorage(t.TempDir(), "/uploads")
+	service := NewImageService(store)
+	ctx := context.Background()
+
+	// Снимок 64x32, который нужно повернуть на 90° по часовой стрелке: красная половина оказывается сверху
+	stored, err := service.StoreImage(ctx, "avatars", encodeOrientedJPEG(t, 64, 32, 6, binary.BigEndian), ProjectImageVariants)
+	if err != nil {
+		t.Fatalf("StoreImage: %v", err)
+	}
+	key, _ := store.KeyForURL(stored.URL)
+	data, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got := jpegOrientation(data); got != 1 {
+		t.Fatalf("stored orientation = %d, want no EXIF", got)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := img.Bounds().Size(); size != image.Pt(32, 64) {
+		t.Fatalf("size = %v, want 32x64", size)
+	}
+	if r, _, b, _ := img.At(16, 8).RGBA(); r>>8 < 200 || b>>8 > 60 {
+		t.Errorf("top = (%d, %d), want red", r>>8, b>>8)
+	}
+	if r, _, b, _ := img.At(16, 56).RGBA(); b>>8 < 200 || r>>8 > 60 {
+		t.Errorf("bottom = (%d, %d), want blue", r>>8, b>>8)
+	}
+}
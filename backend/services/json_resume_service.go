@@ -0,0 +1,447 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+)
+
+// JSONResumeSchemaURL адрес схемы JSON Resume, указываемый при экспорте
+const JSONResumeSchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// maxHighlightSummaryLength максимальная длина краткого содержания раздела подробного описания в highlights
+const maxHighlightSummaryLength = 200
+
+// highlightSections разделы подробного описания, краткое содержание которых попадает в highlights
+var highlightSections = map[string]bool{
+	models.CaseStudyProblem:  true,
+	models.CaseStudyApproach: true,
+	models.CaseStudyResults:  true,
+}
+
+// ErrInvalidJSONResume возвращается, если в импортируемом документе нет обязательных данных
+var ErrInvalidJSONResume = errors.New("JSON Resume document must contain basics.name")
+
+// JSONResumeService выполняет импорт и экспорт профиля в формате JSON Resume
+type JSONResumeService struct {
+	userRepo       *repositories.UserRepository
+	projectRepo    *repositories.ProjectRepository
+	projectService *ProjectService
+}
+
+// NewJSONResumeService создает новый сервис JSON Resume.
+// Проекты при импорте создаются и изменяются через ProjectService, чтобы проходить те же проверки и сохранять версии.
+func NewJSONResumeService(userRepo *repositories.UserRepository, projectRepo *repositories.ProjectRepository, projectService *ProjectService) *JSONResumeService {
+	return &JSONResumeService{
+		userRepo:       userRepo,
+		projectRepo:    projectRepo,
+		projectService: projectService,
+	}
+}
+
+// Export формирует JSON Resume по профилю и проектам пользователя.
+// Для всех, кроме владельца, учитываются настройки приватности профиля, в том числе запрет резюме.
+func (s *JSONResumeService) Export(ctx context.Context, userID, viewerID string) (models.JSONResume, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return models.JSONResume{}, err
+	}
+	if viewerID != userID {
+		if user.Privacy.ResumeDisabled {
+			return models.JSONResume{}, ErrResumeDisabled
+		}
+		user = user.PublicView()
+	}
+
+	projects, err := s.projectRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return models.JSONResume{}, err
+	}
+	projects = onlyPublished(projects)
+
+	resume := models.JSONResume{
+		Schema: JSONResumeSchemaURL,
+		Basics: models.JSONResumeBasics{
+			Name:     user.Name,
+			Label:    user.Title,
+			Image:    user.Avatar,
+			Email:    user.Email,
+			URL:      user.Social.Website,
+			Summary:  user.Bio,
+			Profiles: socialToProfiles(user.Social),
+		},
+		Meta: &models.JSONResumeMeta{
+			Version:      "v1.0.0",
+			LastModified: user.UpdatedAt.UTC().Format(time.RFC3339),
+		},
+	}
+
+	for _, skill := range user.Skills {
+		resume.Skills = append(resume.Skills, models.JSONResumeSkill{Name: skill})
+	}
+
+	for _, project := range projects {
+		item := models.JSONResumeProject{
+			Name:        project.Title,
+			Description: project.Description,
+			Keywords:    project.Technologies,
+			URL:         project.LiveURL,
+		}
+		if item.URL == "" {
+			item.URL = project.GitHubURL
+		}
+		for _, section := range project.CaseStudy {
+			if section.Type == models.CaseStudyRole && section.Content != "" {
+				item.Roles = []string{section.Content}
+			}
+		}
+		item.Highlights = caseStudyHighlights(project.CaseStudy)
+		if !project.CreatedAt.IsZero() {
+			item.StartDate = project.CreatedAt.UTC().Format("2006-01-02")
+		}
+		resume.Projects = append(resume.Projects, item)
+	}
+
+	return resume, nil
+}
+
+// Import обновляет профиль и проекты пользователя по документу JSON Resume.
+// Проекты сопоставляются по ссылке, а затем по названию; несовпавшие создаются.
+// В режиме dryRun изменения только вычисляются и не сохраняются.
+func (s *JSONResumeService) Import(ctx context.Context, userID string, resume models.JSONResume, dryRun bool) (models.ResumeImportResult, error) {
+	result := models.ResumeImportResult{
+		DryRun:          dryRun,
+		ProfileChanges:  []models.FieldChange{},
+		CreatedProjects: []string{},
+		UpdatedProjects: []models.ProjectChange{},
+		SkippedProjects: []string{},
+	}
+
+	if strings.TrimSpace(resume.Basics.Name) == "" {
+		return result, ErrInvalidJSONResume
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return result, err
+	}
+
+	// Профиль: пустые значения в документе не затирают данные платформы
+	updated := user
+	setIfPresent(&updated.Name, resume.Basics.Name)
+	setIfPresent(&updated.Title, resume.Basics.Label)
+	setIfPresent(&updated.Bio, resume.Basics.Summary)
+	setIfPresent(&updated.Avatar, resume.Basics.Image)
+	setIfPresent(&updated.Social.Website, resume.Basics.URL)
+	for _, profile := range resume.Basics.Profiles {
+		if field := socialField(&updated.Social, profile.Network); field != nil {
+			setIfPresent(field, profileURL(profile))
+		}
+	}
+	if len(resume.Skills) > 0 {
+		skills := make([]string, 0, len(resume.Skills))
+		for _, skill := range resume.Skills {
+			if name := strings.TrimSpace(skill.Name); name != "" {
+				skills = append(skills, name)
+			}
+		}
+		updated.Skills = skills
+	}
+
+	result.ProfileChanges = diffFields([]fieldPair{
+		{"name", user.Name, updated.Name},
+		{"title", user.Title, updated.Title},
+		{"bio", user.Bio, updated.Bio},
+		{"avatar", user.Avatar, updated.Avatar},
+		{"skills", user.Skills, updated.Skills},
+		{"social.github", user.Social.GitHub, updated.Social.GitHub},
+		{"social.twitter", user.Social.Twitter, updated.Social.Twitter},
+		{"social.linkedin", user.Social.LinkedIn, updated.Social.LinkedIn},
+		{"social.website", user.Social.Website, updated.Social.Website},
+	})
+
+	if !dryRun && len(result.ProfileChanges) > 0 {
+		if err := renderUserBio(&updated); err != nil {
+			return result, err
+		}
+		if err := s.userRepo.Update(ctx, userID, updated); err != nil {
+			return result, err
+		}
+	}
+
+	existing, err := s.projectRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return result, err
+	}
+
+	// Проекты, создаваемые этим импортом: повторные записи о них в документе пропускаются,
+	// чтобы dry-run и настоящий импорт сообщали одно и то же
+	var planned []models.Project
+	for _, item := range resume.Projects {
+		if strings.TrimSpace(item.Name) == "" {
+			continue
+		}
+		if _, duplicate := matchProject(planned, item); duplicate {
+			result.SkippedProjects = append(result.SkippedProjects, item.Name)
+			continue
+		}
+
+		project, found := matchProject(existing, item)
+		before := project
+
+		project.Title = item.Name
+		// Highlights, полученные экспортом из подробного описания, не дописываются в описание повторно
+		setIfPresent(&project.Description, projectDescription(item, caseStudyHighlights(project.CaseStudy)))
+		if len(item.Keywords) > 0 {
+			project.Technologies = item.Keywords
+		}
+		if item.URL != "" {
+			if isGitHubURL(item.URL) {
+				project.GitHubURL = item.URL
+			} else {
+				project.LiveURL = item.URL
+			}
+		}
+
+		if !found {
+			result.CreatedProjects = append(result.CreatedProjects, project.Title)
+			planned = append(planned, project)
+			if dryRun {
+				continue
+			}
+			project.UserID = user.ID
+			if _, err := s.projectService.CreateProject(ctx, project); err != nil {
+				return result, err
+			}
+			continue
+		}
+
+		changes := diffFields([]fieldPair{
+			{"title", before.Title, project.Title},
+			{"description", before.Description, project.Description},
+			{"technologies", before.Technologies, project.Technologies},
+			{"githubUrl", before.GitHubURL, project.GitHubURL},
+			{"liveUrl", before.LiveURL, project.LiveURL},
+		})
+		if len(changes) == 0 {
+			continue
+		}
+
+		result.UpdatedProjects = append(result.UpdatedProjects, models.ProjectChange{
+			ID:      project.ID.Hex(),
+			Title:   project.Title,
+			Changes: changes,
+		})
+		if dryRun {
+			continue
+		}
+		// Названия полей совпадают с JSON-полями проекта, поэтому изменения передаются как merge patch
+		patch := make(map[string]interface{}, len(changes))
+		for _, change := range changes {
+			patch[change.Field] = change.New
+		}
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return result, err
+		}
+		patched, err := s.projectService.PatchProject(ctx, project.ID.Hex(), data, userID)
+		if err != nil {
+			return result, err
+		}
+		replaceProject(existing, patched)
+	}
+
+	return result, nil
+}
+
+// replaceProject заменяет проект с тем же ID его новой версией
+func replaceProject(projects []models.Project, project models.Project) {
+	for i := range projects {
+		if projects[i].ID == project.ID {
+			projects[i] = project
+			return
+		}
+	}
+}
+
+// fieldPair значения поля до и после изменения
+type fieldPair struct {
+	field string
+	old   interface{}
+	new   interface{}
+}
+
+// diffFields возвращает список изменившихся полей
+func diffFields(pairs []fieldPair) []models.FieldChange {
+	changes := []models.FieldChange{}
+	for _, pair := range pairs {
+		if !reflect.DeepEqual(pair.old, pair.new) {
+			changes = append(changes, models.FieldChange{Field: pair.field, Old: pair.old, New: pair.new})
+		}
+	}
+	return changes
+}
+
+// setIfPresent присваивает значение, только если оно не пустое
+func setIfPresent(target *string, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		*target = value
+	}
+}
+
+// matchProject ищет существующий проект по ссылке или названию
+func matchProject(projects []models.Project, item models.JSONResumeProject) (models.Project, bool) {
+	if item.URL != "" {
+		for _, project := range projects {
+			if sameURL(project.GitHubURL, item.URL) || sameURL(project.LiveURL, item.URL) {
+				return project, true
+			}
+		}
+	}
+	for _, project := range projects {
+		if strings.EqualFold(strings.TrimSpace(project.Title), strings.TrimSpace(item.Name)) {
+			return project, true
+		}
+	}
+	return models.Project{}, false
+}
+
+// caseStudyHighlights формирует highlights проекта: краткое содержание разделов problem, approach и results
+// и метрики результатов в виде "Название: значение"
+func caseStudyHighlights(sections []models.CaseStudySection) []string {
+	var highlights []string
+	for _, section := range sections {
+		if !highlightSections[section.Type] {
+			continue
+		}
+		if summary := caseStudySummary(section.Content); summary != "" {
+			highlights = append(highlights, caseStudyTitle(section.Type)+": "+summary)
+		}
+		for _, metric := range section.Metrics {
+			highlights = append(highlights, metric.Label+": "+metric.Value)
+		}
+	}
+	return highlights
+}
+
+// caseStudySummary возвращает первый абзац раздела в одну строку, сокращенный до maxHighlightSummaryLength символов
+func caseStudySummary(content string) string {
+	paragraph := strings.TrimSpace(content)
+	if i := strings.Index(paragraph, "\n\n"); i >= 0 {
+		paragraph = paragraph[:i]
+	}
+	paragraph = strings.Join(strings.Fields(paragraph), " ")
+	if utf8.RuneCountInString(paragraph) <= maxHighlightSummaryLength {
+		return paragraph
+	}
+	runes := []rune(paragraph)
+	return strings.TrimSpace(string(runes[:maxHighlightSummaryLength-1])) + "…"
+}
+
+// projectDescription объединяет описание проекта и его ключевые достижения, кроме перечисленных в exported
+func projectDescription(item models.JSONResumeProject, exported []string) string {
+	parts := []string{}
+	if item.Description != "" {
+		parts = append(parts, item.Description)
+	}
+	for _, highlight := range item.Highlights {
+		if !containsString(exported, highlight) {
+			parts = append(parts, "- "+highlight)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// socialToProfiles преобразует социальные ссылки в профили JSON Resume
+func socialToProfiles(social models.Social) []models.JSONResumeProfile {
+	profiles := []models.JSONResumeProfile{}
+	for _, link := range []struct{ network, url string }{
+		{"GitHub", social.GitHub},
+		{"Twitter", social.Twitter},
+		{"LinkedIn", social.LinkedIn},
+	} {
+		if link.url == "" {
+			continue
+		}
+		profiles = append(profiles, models.JSONResumeProfile{
+			Network:  link.network,
+			Username: usernameFromURL(link.url),
+			URL:      link.url,
+		})
+	}
+	return profiles
+}
+
+// socialField возвращает поле Social, соответствующее сети профиля JSON Resume
+func socialField(social *models.Social, network string) *string {
+	switch strings.ToLower(strings.TrimSpace(network)) {
+	case "github":
+		return &social.GitHub
+	case "twitter", "x":
+		return &social.Twitter
+	case "linkedin":
+		return &social.LinkedIn
+	case "website", "homepage", "blog":
+		return &social.Website
+	}
+	return nil
+}
+
+// profileURL возвращает ссылку на профиль, восстанавливая ее по имени пользователя при необходимости
+func profileURL(profile models.JSONResumeProfile) string {
+	if profile.URL != "" || profile.Username == "" {
+		return profile.URL
+	}
+	switch strings.ToLower(profile.Network) {
+	case "github":
+		return "https://github.com/" + profile.Username
+	case "twitter", "x":
+		return "https://twitter.com/" + profile.Username
+	case "linkedin":
+		return "https://www.linkedin.com/in/" + profile.Username
+	}
+	return ""
+}
+
+// usernameFromURL извлекает имя пользователя из последнего сегмента ссылки на профиль
+func usernameFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	return segments[len(segments)-1]
+}
+
+// isGitHubURL проверяет, указывает ли ссылка на github.com
+func isGitHubURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	return host == "github.com" || host == "www.github.com"
+}
+
+// sameURL сравнивает ссылки без учета схемы, регистра хоста и завершающего слеша
+func sameURL(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	normalize := func(raw string) string {
+		raw = strings.TrimSpace(strings.ToLower(raw))
+		raw = strings.TrimPrefix(raw, "https://")
+		raw = strings.TrimPrefix(raw, "http://")
+		raw = strings.TrimPrefix(raw, "www.")
+		return strings.TrimSuffix(raw, "/")
+	}
+	return normalize(a) == normalize(b)
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestImportDryRunSkipsRepeatedProjects(t *testing.T) {
+	newMockDB(t, "dry run", func(mt *mtest.T) {
+		service := NewJSONResumeService(
+			repositories.NewUserRepository(mt.Client, "test"),
+			repositories.NewProjectRepository(mt.Client, "test"),
+			newTestProjectService(mt),
+		)
+		user := models.User{ID: primitive.NewObjectID(), Name: "Ada"}
+		existing := models.Project{
+			ID:        primitive.NewObjectID(),
+			UserID:    user.ID,
+			Title:     "Engine",
+			GitHubURL: "https://github.com/ada/engine",
+			CaseStudy: []models.CaseStudySection{{Type: models.CaseStudyProblem, Content: "Tables by hand."}},
+		}
+		mt.AddMockResponses(
+			findResponse("test.users", mockDocuments(mt.T, user)...),
+			findResponse("test.projects", mockDocuments(mt.T, existing)...),
+		)
+
+		resume := models.JSONResume{
+			Basics: models.JSONResumeBasics{Name: "Ada"},
+			Projects: []models.JSONResumeProject{
+				{Name: "App", URL: "https://github.com/ada/app"},
+				// Тот же проект по названию и по ссылке
+				{Name: " app "},
+				{Name: "App v2", URL: "https://github.com/ada/app/"},
+				{Name: "Site", URL: "https://ada.dev"},
+				// Highlight из подробного описания, выгруженный экспортом, в описание не попадает
+				{Name: "Engine", URL: "https://github.com/ada/engine", Description: "Analytical", Highlights: []string{"Problem: Tables by hand.", "Fast"}},
+			},
+		}
+		result, err := service.Import(context.Background(), user.ID.Hex(), resume, true)
+		if err != nil {
+			mt.Fatalf("Import: %v", err)
+		}
+
+		if want := []string{"App", "Site"}; !reflect.DeepEqual(result.CreatedProjects, want) {
+			mt.Errorf("created = %v, want %v", result.CreatedProjects, want)
+		}
+		if want := []string{" app ", "App v2"}; !reflect.DeepEqual(result.SkippedProjects, want) {
+			mt.Errorf("skipped = %v, want %v", result.SkippedProjects, want)
+		}
+		if len(result.UpdatedProjects) != 1 || result.UpdatedProjects[0].ID != existing.ID.Hex() {
+			mt.Fatalf("updated = %+v, want %s", result.UpdatedProjects, existing.ID.Hex())
+		}
+		want := []models.FieldChange{{Field: "description", Old: "", New: "Analytical\n- Fast"}}
+		if changes := result.UpdatedProjects[0].Changes; !reflect.DeepEqual(changes, want) {
+			mt.Errorf("changes = %+v, want %+v", changes, want)
+		}
+	})
+}
+
+func TestExportMapsCaseStudyToHighlights(t *testing.T) {
+	newMockDB(t, "export", func(mt *mtest.T) {
+		service := NewJSONResumeService(
+			repositories.NewUserRepository(mt.Client, "test"),
+			repositories.NewProjectRepository(mt.Client, "test"),
+			nil,
+		)
+		user := models.User{ID: primitive.NewObjectID(), Name: "Ada"}
+		project := models.Project{
+			ID:     primitive.NewObjectID(),
+			UserID: user.ID,
+			Title:  "Engine",
+			Status: models.ProjectPublished,
+			CaseStudy: []models.CaseStudySection{
+				{Type: models.CaseStudyProblem, Content: "Tables were\ncomputed by hand.\n\nErrors were common."},
+				{Type: models.CaseStudyApproach, Content: strings.Repeat("a", 300)},
+				{Type: models.CaseStudyArchitecture, Content: "Mill and store."},
+				{Type: models.CaseStudyResults, Metrics: []models.CaseStudyMetric{{Label: "Errors", Value: "-100%"}, {Label: "Speed", Value: "10x"}}},
+				{Type: models.CaseStudyRole, Content: "Designer"},
+			},
+		}
+		mt.AddMockResponses(
+			findResponse("test.users", mockDocuments(mt.T, user)...),
+			findResponse("test.projects", mockDocuments(mt.T, project)...),
+		)
+
+		resume, err := service.Export(context.Background(), user.ID.Hex(), user.ID.Hex())
+		if err != nil {
+			mt.Fatalf("Export: %v", err)
+		}
+		if len(resume.Projects) != 1 {
+			mt.Fatalf("projects = %+v", resume.Projects)
+		}
+		item := resume.Projects[0]
+		want := []string{
+			"Problem: Tables were computed by hand.",
+			"Approach: " + strings.Repeat("a", maxHighlightSummaryLength-1) + "…",
+			"Errors: -100%",
+			"Speed: 10x",
+		}
+		if !reflect.DeepEqual(item.Highlights, want) {
+			mt.Errorf("highlights = %q, want %q", item.Highlights, want)
+		}
+		if !reflect.DeepEqual(item.Roles, []string{"Designer"}) {
+			mt.Errorf("roles = %q", item.Roles)
+		}
+	})
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"your-project/backend/linkcheck"
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// LinkCheckInterval период фоновой проверки ссылок
+	LinkCheckInterval = time.Hour
+	// LinkCheckStaleAfter возраст результата проверки, после которого ссылка проверяется снова
+	LinkCheckStaleAfter = 24 * time.Hour
+	// LinkCheckCooldown минимальный интервал между проверками ссылок пользователя по запросу
+	LinkCheckCooldown = 5 * time.Minute
+	// linkHistorySize количество последних проверок, хранимых для ссылки
+	linkHistorySize = 20
+)
+
+// LinkCheckRateLimitError возвращается, если пользователь запрашивает проверку ссылок слишком часто
+type LinkCheckRateLimitError struct {
+	// RetryAt момент, после которого можно повторить запрос
+	RetryAt time.Time
+}
+
+func (e *LinkCheckRateLimitError) Error() string {
+	return fmt.Sprintf("links were checked recently, retry after %s", e.RetryAt.UTC().Format(time.RFC3339))
+}
+
+// LinkCheckService проверяет ссылки из профилей и проектов и хранит историю проверок
+type LinkCheckService struct {
+	linkRepo    *repositories.LinkCheckRepository
+	userRepo    *repositories.UserRepository
+	projectRepo *repositories.ProjectRepository
+	checker     *linkcheck.Checker
+	now         func() time.Time
+
+	mu sync.Mutex
+	// manualChecks время последней проверки по запросу для каждого пользователя
+	manualChecks map[primitive.ObjectID]time.Time
+}
+
+// NewLinkCheckService создает новый сервис проверки ссылок
+func NewLinkCheckService(linkRepo *repositories.LinkCheckRepository, userRepo *repositories.UserRepository, projectRepo *repositories.ProjectRepository, checker *linkcheck.Checker) *LinkCheckService {
+	return &LinkCheckService{
+		linkRepo:    linkRepo,
+		userRepo:    userRepo,
+		projectRepo: projectRepo,
+		checker:     checker,
+		now:         time.Now,
+
+		manualChecks: make(map[primitive.ObjectID]time.Time),
+	}
+}
+
+// GetReport возвращает состояние ссылок пользователя по результатам последних проверок
+func (s *LinkCheckService) GetReport(ctx context.Context, userID string) (models.LinkReport, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return models.LinkReport{}, err
+	}
+
+	links, err := s.linkRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return models.LinkReport{}, err
+	}
+	return newLinkReport(links), nil
+}
+
+// CheckUser сразу проверяет все ссылки пользователя и возвращает их состояние.
+// Проверка по запросу доступна не чаще одного раза в LinkCheckCooldown.
+func (s *LinkCheckService) CheckUser(ctx context.Context, userID string) (models.LinkReport, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return models.LinkReport{}, err
+	}
+	if err := s.reserveManualCheck(user.ID); err != nil {
+		return models.LinkReport{}, err
+	}
+
+	if _, err := s.checkUser(ctx, user, true); err != nil {
+		return models.LinkReport{}, err
+	}
+	return s.GetReport(ctx, userID)
+}
+
+// CheckStale проверяет ссылки всех пользователей, результаты проверки которых устарели,
+// и возвращает количество проверенных ссылок
+func (s *LinkCheckService) CheckStale(ctx context.Context) (int, error) {
+	users, err := s.userRepo.FindAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	checked := 0
+	for _, user := range users {
+		if ctx.Err() != nil {
+			return checked, ctx.Err()
+		}
+		count, err := s.checkUser(ctx, user, false)
+		if err != nil {
+			// Ошибка одного пользователя не мешает проверке остальных
+			log.Printf("Failed to check links of user %s: %v", user.ID.Hex(), err)
+		}
+		checked += count
+	}
+
+	return checked, nil
+}
+
+// Run периодически проверяет ссылки с устаревшими результатами, пока не будет отменен ctx
+func (s *LinkCheckService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if checked, err := s.CheckStale(ctx); err != nil {
+			log.Printf("Link check stopped: %v", err)
+		} else if checked > 0 {
+			log.Printf("Link check verified %d links", checked)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reserveManualCheck отмечает проверку по запросу или возвращает LinkCheckRateLimitError,
+// если предыдущая была недавно
+func (s *LinkCheckService) reserveManualCheck(userID primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if last, ok := s.manualChecks[userID]; ok && now.Sub(last) < LinkCheckCooldown {
+		return &LinkCheckRateLimitError{RetryAt: last.Add(LinkCheckCooldown)}
+	}
+
+	// Устаревшие отметки больше не ограничивают запросы
+	for id, last := range s.manualChecks {
+		if now.Sub(last) >= LinkCheckCooldown {
+			delete(s.manualChecks, id)
+		}
+	}
+	s.manualChecks[userID] = now
+	return nil
+}
+
+// checkUser проверяет ссылки пользователя; без force пропускает недавно проверенные ссылки.
+// Состояние ссылок, которых больше нет, удаляется. Возвращает количество проверенных ссылок.
+func (s *LinkCheckService) checkUser(ctx context.Context, user models.User, force bool) (int, error) {
+	links, err := s.collectLinks(ctx, user)
+	if err != nil {
+		return 0, err
+	}
+
+	stored, err := s.linkRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return 0, err
+	}
+	previous := make(map[string]models.LinkCheck, len(stored))
+	for _, link := range stored {
+		previous[linkKey(link)] = link
+	}
+
+	checked := 0
+	for _, link := range links {
+		key := linkKey(link)
+		last, found := previous[key]
+		delete(previous, key)
+
+		sameURL := found && last.URL == link.URL
+		if !force && sameURL && s.now().Sub(last.CheckedAt) < LinkCheckStaleAfter {
+			continue
+		}
+
+		if sameURL {
+			link.History = last.History
+			link.BrokenSince = last.BrokenSince
+		}
+		s.applyResult(&link, s.checker.Check(ctx, link.URL))
+		if err := s.linkRepo.Save(ctx, link); err != nil {
+			return checked, err
+		}
+		checked++
+	}
+
+	// Оставшиеся ссылки удалены из профиля или проектов
+	removed := make([]primitive.ObjectID, 0, len(previous))
+	for _, link := range previous {
+		removed = append(removed, link.ID)
+	}
+	return checked, s.linkRepo.DeleteByIDs(ctx, removed)
+}
+
+// collectLinks возвращает непустые ссылки из социальных сетей пользователя и его проектов
+func (s *LinkCheckService) collectLinks(ctx context.Context, user models.User) ([]models.LinkCheck, error) {
+	var links []models.LinkCheck
+	add := func(project *models.Project, field, url string) {
+		if url == "" {
+			return
+		}
+		link := models.LinkCheck{UserID: user.ID, Field: field, URL: url}
+		if project != nil {
+			projectID := project.ID
+			link.ProjectID = &projectID
+			link.ProjectTitle = project.Title
+		}
+		links = append(links, link)
+	}
+
+	add(nil, models.LinkFieldWebsite, user.Social.Website)
+	add(nil, models.LinkFieldGitHub, user.Social.GitHub)
+	add(nil, models.LinkFieldTwitter, user.Social.Twitter)
+	add(nil, models.LinkFieldLinkedIn, user.Social.LinkedIn)
+
+	projects, err := s.projectRepo.FindByUserID(ctx, user.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		add(&projects[i], models.LinkFieldLiveURL, projects[i].LiveURL)
+		add(&projects[i], models.LinkFieldGitHubURL, projects[i].GitHubURL)
+	}
+
+	return links, nil
+}
+
+// applyResult записывает результат проверки в состояние ссылки и историю
+func (s *LinkCheckService) applyResult(link *models.LinkCheck, result linkcheck.Result) {
+	now := s.now()
+	entry := models.LinkCheckResult{
+		Status:     models.LinkStatusOK,
+		StatusCode: result.StatusCode,
+		Error:      result.Error,
+		DurationMs: result.Duration.Milliseconds(),
+		CheckedAt:  now,
+	}
+	switch {
+	case result.Skipped:
+		entry.Status = models.LinkStatusSkipped
+	case !result.OK:
+		entry.Status = models.LinkStatusBroken
+	}
+
+	link.Status = entry.Status
+	link.StatusCode = entry.StatusCode
+	link.Error = entry.Error
+	link.CheckedAt = now
+
+	// Пропущенная проверка не меняет отметку о неработающей ссылке
+	switch entry.Status {
+	case models.LinkStatusOK:
+		link.BrokenSince = nil
+	case models.LinkStatusBroken:
+		if link.BrokenSince == nil {
+			link.BrokenSince = &now
+		}
+	}
+
+	link.History = append(link.History, entry)
+	if len(link.History) > linkHistorySize {
+		link.History = link.History[len(link.History)-linkHistorySize:]
+	}
+}
+
+// linkKey возвращает ключ ссылки: проект и поле
+func linkKey(link models.LinkCheck) string {
+	if link.ProjectID == nil {
+		return link.Field
+	}
+	return link.ProjectID.Hex() + "/" + link.Field
+}
+
+// newLinkReport формирует отчет о ссылках, начиная с неработающих
+func newLinkReport(links []models.LinkCheck) models.LinkReport {
+	sort.SliceStable(links, func(i, j int) bool {
+		return links[i].Status == models.LinkStatusBroken && links[j].Status != models.LinkStatusBroken
+	})
+
+	report := models.LinkReport{Total: len(links), Links: links}
+	for _, link := range links {
+		if link.Status == models.LinkStatusBroken {
+			report.Broken++
+		}
+	}
+	return report
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"bytes"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"your-project/backend/models"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/net/html"
+)
+
+// markdownHighlightStyle стиль подсветки синтаксиса, CSS которого отдает MarkdownStylesheet
+const markdownHighlightStyle = "github"
+
+// markdownRenderer преобразует Markdown (GFM) в HTML. Встроенный HTML не выводится,
+// опасные ссылки (javascript: и т.п.) отбрасываются.
+var markdownRenderer = goldmark.New(
+	goldmark.WithExtensions(
+		// Расширения GFM: таблицы, зачеркивание, автоссылки и списки задач
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough,
+		extension.Linkify,
+		extension.TaskList,
+		// Подсветка синтаксиса в блоках кода; цвета задаются классами, а не атрибутами style
+		highlighting.NewHighlighting(
+			highlighting.WithStyle(markdownHighlightStyle),
+			highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+		),
+	),
+)
+
+// allowedHTMLAttributes разрешенные теги и их атрибуты в HTML, полученном из Markdown
+var allowedHTMLAttributes = map[string][]string{
+	"p": nil, "br": nil, "hr": nil, "blockquote": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"ul": nil, "ol": {"start"}, "li": nil,
+	"pre": {"class"}, "code": {"class"}, "span": {"class"},
+	"em": nil, "strong": nil, "del": nil,
+	"a":     {"href", "title"},
+	"img":   {"src", "alt", "title"},
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil,
+	"th":    {"align"},
+	"td":    {"align"},
+	"input": {"type", "checked", "disabled"},
+}
+
+// droppedHTMLElements элементы, которые удаляются вместе с содержимым
+var droppedHTMLElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "template": true, "textarea": true, "select": true, "svg": true, "math": true,
+}
+
+var (
+	// codeClassPattern допустимые классы блоков кода и подсветки синтаксиса
+	codeClassPattern = regexp.MustCompile(`^[A-Za-z0-9_+#. -]+$`)
+	// numberPattern допустимое значение атрибута start
+	numberPattern = regexp.MustCompile(`^[0-9]{1,9}$`)
+)
+
+// RenderMarkdown преобразует Markdown в безопасный HTML
+func RenderMarkdown(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdownRenderer.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return SanitizeHTML(buf.String()), nil
+}
+
+// renderProjectDescription сохраняет в проекте HTML, полученный из Markdown описания
+func renderProjectDescription(project *models.Project) error {
+	rendered, err := RenderMarkdown(project.Description)
+	if err != nil {
+		return err
+	}
+	project.DescriptionHTML = rendered
+	return nil
+}
+
+// renderUserBio сохраняет в профиле HTML, полученный из Markdown биографии
+func renderUserBio(user *models.User) error {
+	rendered, err := RenderMarkdown(user.Bio)
+	if err != nil {
+		return err
+	}
+	user.BioHTML = rendered
+	return nil
+}
+
+// renderPatchedMarkdown добавляет в обновление HTML поля htmlField, если патч меняет
+// его Markdown-источник sourceField; rendered - HTML из нового значения источника
+func renderPatchedMarkdown(update *FieldUpdate, sourceField, htmlField, rendered string) {
+	if _, ok := update.Set[sourceField]; ok {
+		update.Set[htmlField] = rendered
+	}
+	if containsString(update.Unset, sourceField) && !containsString(update.Unset, htmlField) {
+		update.Unset = append(update.Unset, htmlField)
+	}
+}
+
+// MarkdownStylesheet возвращает CSS для классов подсветки синтаксиса в HTML, полученном из Markdown
+func MarkdownStylesheet() (string, error) {
+	var buf bytes.Buffer
+	formatter := chromahtml.New(chromahtml.WithClasses(true))
+	if err := formatter.WriteCSS(&buf, styles.Get(markdownHighlightStyle)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// SanitizeHTML оставляет в HTML только разрешенные теги и атрибуты и безопасные ссылки
+func SanitizeHTML(input string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	var out strings.Builder
+	// skipDepth - глубина вложенности внутри удаляемого элемента
+	skipDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			return out.String()
+		}
+		token := tokenizer.Token()
+
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedHTMLElements[token.Data] {
+				if tokenType == html.StartTagToken {
+					skipDepth++
+				}
+				continue
+			}
+			if skipDepth > 0 {
+				continue
+			}
+			if allowed, ok := allowedHTMLAttributes[token.Data]; ok {
+				if tag, ok := sanitizeTag(token, allowed); ok {
+					out.WriteString(tag)
+				}
+			}
+		case html.EndTagToken:
+			if droppedHTMLElements[token.Data] {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if skipDepth > 0 {
+				continue
+			}
+			if _, ok := allowedHTMLAttributes[token.Data]; ok {
+				out.WriteString("</" + token.Data + ">")
+			}
+		case html.TextToken:
+			if skipDepth == 0 {
+				out.WriteString(html.EscapeString(token.Data))
+			}
+		}
+	}
+}
+
+// sanitizeTag формирует открывающий тег только с разрешенными атрибутами.
+// Возвращает false, если тег нужно отбросить целиком.
+func sanitizeTag(token html.Token, allowed []string) (string, bool) {
+	var buf strings.Builder
+	buf.WriteString("<" + token.Data)
+
+	for _, attr := range token.Attr {
+		if attr.Namespace != "" || !containsString(allowed, attr.Key) {
+			continue
+		}
+
+		value := attr.Val
+		switch attr.Key {
+		case "href":
+			if !isSafeURL(value, true) {
+				continue
+			}
+		case "src":
+			if !isSafeURL(value, false) {
+				continue
+			}
+		case "class":
+			if !codeClassPattern.MatchString(value) {
+				continue
+			}
+		case "start":
+			if !numberPattern.MatchString(value) {
+				continue
+			}
+		case "align":
+			if value != "left" && value != "center" && value != "right" {
+				continue
+			}
+		case "type":
+			if value != "checkbox" {
+				return "", false
+			}
+		}
+
+		buf.WriteString(" " + attr.Key + `="` + html.EscapeString(value) + `"`)
+	}
+
+	switch token.Data {
+	case "a":
+		// Ссылки из пользовательского контента не передают вес и не дают доступ к window.opener
+		buf.WriteString(` rel="nofollow ugc noopener"`)
+	case "input":
+		// Из Markdown допустимы только отключенные флажки списков задач
+		if !strings.Contains(buf.String(), `type="checkbox"`) {
+			return "", false
+		}
+		if !strings.Contains(buf.String(), " disabled=") {
+			buf.WriteString(` disabled=""`)
+		}
+	}
+
+	buf.WriteString(">")
+	return buf.String(), true
+}
+
+// isSafeURL разрешает http(s) и относительные ссылки, а для href также mailto
+func isSafeURL(raw string, allowMailto bool) bool {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https":
+		return true
+	case "mailto":
+		return allowMailto
+	case "":
+		return true
+	}
+	return false
+}
+
+// containsString проверяет наличие строки в срезе
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "javascript link",
+			input: `<a href="javascript:alert(1)">x</a>`,
+			want:  `<a rel="nofollow ugc noopener">x</a>`,
+		},
+		{
+			name:  "javascript link with encoded colon",
+			input: `<a href="javascript&#58;alert(1)">x</a>`,
+			want:  `<a rel="nofollow ugc noopener">x</a>`,
+		},
+		{
+			name:  "javascript link with encoded tab",
+			input: `<a href="java&#x09;script:alert(1)">x</a>`,
+			want:  `<a rel="nofollow ugc noopener">x</a>`,
+		},
+		{
+			name:  "javascript link with named entity and mixed case",
+			input: `<a href=" JavaScript&colon;alert(1)">x</a>`,
+			want:  `<a rel="nofollow ugc noopener">x</a>`,
+		},
+		{
+			name:  "safe links",
+			input: `<a href="https://example.com/?a=1&amp;b=2" title="t">x</a><a href="/docs">y</a><a href="mailto:me@example.com">z</a>`,
+			want: `<a href="https://example.com/?a=1&amp;b=2" title="t" rel="nofollow ugc noopener">x</a>` +
+				`<a href="/docs" rel="nofollow ugc noopener">y</a>` +
+				`<a href="mailto:me@example.com" rel="nofollow ugc noopener">z</a>`,
+		},
+		{
+			name:  "script with content",
+			input: `<p>a<script>alert(1)</script>b</p>`,
+			want:  `<p>ab</p>`,
+		},
+		{
+			name:  "event handlers",
+			input: `<img src="x.png" onerror="alert(1)" alt="a"><p onclick="alert(1)">b</p>`,
+			want:  `<img src="x.png" alt="a"><p>b</p>`,
+		},
+		{
+			name:  "svg with nested script",
+			input: `<svg onload="alert(1)"><script>alert(1)</script><a href="https://x">y</a></svg><p>ok</p>`,
+			want:  `<p>ok</p>`,
+		},
+		{
+			name:  "data and javascript image sources",
+			input: `<img src="data:image/png;base64,AAAA" alt="a"><img src="javascript:alert(1)"><img src="mailto:a@b">`,
+			want:  `<img alt="a"><img><img>`,
+		},
+		{
+			name:  "unknown tags keep their text",
+			input: `<div style="color:red"><font>text</font></div>`,
+			want:  `text`,
+		},
+		{
+			name:  "task list checkbox is disabled",
+			input: `<input type="checkbox" checked=""><input type="checkbox" disabled="">`,
+			want:  `<input type="checkbox" checked="" disabled=""><input type="checkbox" disabled="">`,
+		},
+		{
+			name:  "inputs other than checkboxes are dropped",
+			input: `<input type="text" value="x"><input><input type="submit">`,
+			want:  ``,
+		},
+		{
+			name:  "chroma classes pass through",
+			input: `<pre class="chroma"><code class="language-c++"><span class="kd">func</span></code></pre>`,
+			want:  `<pre class="chroma"><code class="language-c++"><span class="kd">func</span></code></pre>`,
+		},
+		{
+			name:  "unexpected class values are dropped",
+			input: `<span class="a&quot;b">x</span><span class="x" style="color:red">y</span>`,
+			want:  `<span>x</span><span class="x">y</span>`,
+		},
+		{
+			name:  "table align values",
+			input: `<th align="left">a</th><th align="center">b</th><td align="right">c</td><td align="justify">d</td><td align="&quot;x">e</td>`,
+			want:  `<th align="left">a</th><th align="center">b</th><td align="right">c</td><td>d</td><td>e</td>`,
+		},
+		{
+			name:  "ordered list start",
+			input: `<ol start="3"></ol><ol start="1; x"></ol>`,
+			want:  `<ol start="3"></ol><ol></ol>`,
+		},
+		{
+			name:  "text is escaped",
+			input: `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`,
+			want:  `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.input); got != tt.want {
+				t.Errorf("SanitizeHTML(%q)\n got %q\nwant %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "javascript link",
+			source:  "[x](javascript:alert(1)) [y](javascript&#58;alert(1)) [z](java&#x09;script:alert(1))",
+			want:    []string{`rel="nofollow ugc noopener"`},
+			notWant: []string{"javascript", "script:"},
+		},
+		{
+			name:    "raw html is not rendered",
+			source:  "<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>\n\n<svg onload=alert(1)></svg>",
+			notWant: []string{"<script", "onerror", "<svg", "onload", "alert"},
+		},
+		{
+			name:    "data image",
+			source:  "![a](data:image/png;base64,AAAA)",
+			want:    []string{`<img alt="a">`},
+			notWant: []string{"data:"},
+		},
+		{
+			name:   "task list",
+			source: "- [x] done\n- [ ] todo",
+			want: []string{
+				`<input checked="" disabled="" type="checkbox"> done`,
+				`<input disabled="" type="checkbox"> todo`,
+			},
+		},
+		{
+			name:   "highlighted code",
+			source: "```go\nfunc main() {}\n```",
+			want:   []string{`<pre class="chroma">`, `<span class="kd">func</span>`, `<span class="nf">main</span>`},
+			// Цвета задаются стилями из MarkdownStylesheet, а не атрибутами
+			notWant: []string{"style="},
+		},
+		{
+			name:   "table alignment",
+			source: "| a | b | c |\n|:--|:-:|--:|\n| 1 | 2 | 3 |",
+			want:   []string{`<th align="left">a</th>`, `<th align="center">b</th>`, `<td align="right">3</td>`},
+		},
+		{
+			name:   "autolink",
+			source: "see https://example.com",
+			want:   []string{`<a href="https://example.com" rel="nofollow ugc noopener">https://example.com</a>`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderMarkdown(tt.source)
+			if err != nil {
+				t.Fatalf("RenderMarkdown: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output %q does not contain %q", got, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("output %q contains %q", got, notWant)
+				}
+			}
+		})
+	}
+}
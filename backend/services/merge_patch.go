@@ -0,0 +1,145 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MIMEMergePatch тип содержимого JSON Merge Patch (RFC 7396)
+const MIMEMergePatch = "application/merge-patch+json"
+
+// ErrInvalidPatch возвращается для некорректного или недопустимого патча
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// FieldUpdate описывает частичное обновление документа: поля для $set и $unset (имена BSON)
+type FieldUpdate struct {
+	Set   map[string]interface{}
+	Unset []string
+}
+
+// IsEmpty сообщает, что обновление ничего не меняет
+func (u FieldUpdate) IsEmpty() bool {
+	return len(u.Set) == 0 && len(u.Unset) == 0
+}
+
+// buildMergePatchUpdate применяет JSON Merge Patch к модели original и возвращает
+// итоговую модель в result, а также обновление только для переданных полей верхнего уровня.
+// Поля из readOnly (имена JSON) и неизвестные поля изменять нельзя.
+func buildMergePatchUpdate(original interface{}, patch []byte, readOnly map[string]bool, result interface{}) (FieldUpdate, error) {
+	var patchDoc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(patch))
+	decoder.UseNumber()
+	if err := decoder.Decode(&patchDoc); err != nil || patchDoc == nil {
+		return FieldUpdate{}, fmt.Errorf("%w: patch must be a JSON object", ErrInvalidPatch)
+	}
+
+	fields := jsonToBSONFields(reflect.TypeOf(result).Elem())
+
+	keys := make([]string, 0, len(patchDoc))
+	for key := range patchDoc {
+		if _, known := fields[key]; !known {
+			return FieldUpdate{}, fmt.Errorf("%w: unknown field %q", ErrInvalidPatch, key)
+		}
+		if readOnly[key] {
+			return FieldUpdate{}, fmt.Errorf("%w: field %q is read-only", ErrInvalidPatch, key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Применяем патч к JSON-представлению исходной модели
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return FieldUpdate{}, err
+	}
+	var target interface{}
+	decoder = json.NewDecoder(bytes.NewReader(originalJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&target); err != nil {
+		return FieldUpdate{}, err
+	}
+
+	merged, err := json.Marshal(mergePatch(target, patchDoc))
+	if err != nil {
+		return FieldUpdate{}, err
+	}
+
+	// Декодирование в модель проверяет типы значений
+	if err := json.Unmarshal(merged, result); err != nil {
+		return FieldUpdate{}, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	update := FieldUpdate{Set: map[string]interface{}{}}
+	value := reflect.ValueOf(result).Elem()
+	for _, key := range keys {
+		field := fields[key]
+		if patchDoc[key] == nil {
+			update.Unset = append(update.Unset, field.bsonName)
+			continue
+		}
+		update.Set[field.bsonName] = value.FieldByIndex(field.index).Interface()
+	}
+
+	return update, nil
+}
+
+// mergePatch реализует алгоритм MergePatch из RFC 7396
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+// modelField описывает поле модели: имя в BSON и индекс в структуре
+type modelField struct {
+	bsonName string
+	index    []int
+}
+
+// jsonToBSONFields строит соответствие имен полей JSON и BSON по тегам структуры
+func jsonToBSONFields(t reflect.Type) map[string]modelField {
+	fields := make(map[string]modelField)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName := tagName(field.Tag.Get("json"))
+		bsonName := tagName(field.Tag.Get("bson"))
+		if jsonName == "-" || bsonName == "-" || !field.IsExported() {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		if bsonName == "" {
+			bsonName = strings.ToLower(field.Name)
+		}
+		fields[jsonName] = modelField{bsonName: bsonName, index: field.Index}
+	}
+	return fields
+}
+
+// tagName возвращает имя из тега вида "name,omitempty"
+func tagName(tag string) string {
+	name, _, _ := strings.Cut(tag, ",")
+	return name
+}
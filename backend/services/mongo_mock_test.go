@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// newMockDB запускает тест с имитацией MongoDB, ответы которой задаются через mt.AddMockResponses
+// в порядке обращений к базе
+func newMockDB(t *testing.T, name string, test func(mt *mtest.T)) {
+	t.Helper()
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+	mt.Run(name, test)
+}
+
+// mockDocuments превращает значения (проекты, пользователей и т.д.) в документы ответа MongoDB
+func mockDocuments(t *testing.T, values ...interface{}) []bson.D {
+	t.Helper()
+	documents := make([]bson.D, 0, len(values))
+	for _, value := range values {
+		data, err := bson.Marshal(value)
+		if err != nil {
+			t.Fatalf("marshal %T: %v", value, err)
+		}
+		var document bson.D
+		if err := bson.Unmarshal(data, &document); err != nil {
+			t.Fatalf("unmarshal %T: %v", value, err)
+		}
+		documents = append(documents, document)
+	}
+	return documents
+}
+
+// findResponse возвращает ответ MongoDB на find с документами в одном пакете
+func findResponse(namespace string, documents ...bson.D) bson.D {
+	return mtest.CreateCursorResponse(0, namespace, mtest.FirstBatch, documents...)
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"bytes"
+	"html/template"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"your-project/backend/models"
+)
+
+// Типы содержимого альтернативных представлений профиля
+const (
+	MIMEVCard  = "text/vcard"
+	MIMEHTML   = "text/html"
+	MIMEJSONLD = "application/ld+json"
+)
+
+// vCardLineLimit максимальная длина строки vCard в октетах (RFC 6350, 3.2)
+const vCardLineLimit = 75
+
+// RenderVCard формирует vCard 4.0 (RFC 6350) по профилю пользователя.
+// profileURL - публичный адрес профиля, используется как UID и SOURCE.
+func RenderVCard(user models.User, profileURL string) string {
+	var lines []string
+	add := func(line string) {
+		lines = append(lines, line)
+	}
+
+	add("BEGIN:VCARD")
+	add("VERSION:4.0")
+	add("KIND:individual")
+	add("FN:" + escapeVCardText(user.Name))
+	add("N:" + vCardStructuredName(user.Name))
+	if user.Title != "" {
+		add("TITLE:" + escapeVCardText(user.Title))
+	}
+	if user.Bio != "" {
+		add("NOTE:" + escapeVCardText(user.Bio))
+	}
+	if user.Email != "" {
+		add("EMAIL;TYPE=work:" + escapeVCardText(user.Email))
+	}
+	if user.Avatar != "" {
+		add("PHOTO:" + absoluteURL(profileURL, user.Avatar))
+	}
+	if user.Social.Website != "" {
+		add("URL:" + user.Social.Website)
+	}
+	for _, link := range []struct{ kind, url string }{
+		{"github", user.Social.GitHub},
+		{"twitter", user.Social.Twitter},
+		{"linkedin", user.Social.LinkedIn},
+	} {
+		if link.url != "" {
+			add("X-SOCIALPROFILE;TYPE=" + link.kind + ":" + link.url)
+		}
+	}
+	if len(user.Skills) > 0 {
+		skills := make([]string, len(user.Skills))
+		for i, skill := range user.Skills {
+			skills[i] = escapeVCardText(skill)
+		}
+		add("CATEGORIES:" + strings.Join(skills, ","))
+	}
+	if profileURL != "" {
+		add("UID:" + profileURL)
+		add("SOURCE:" + profileURL)
+	}
+	if !user.UpdatedAt.IsZero() {
+		add("REV:" + user.UpdatedAt.UTC().Format("20060102T150405Z"))
+	}
+	add("END:VCARD")
+
+	var buf strings.Builder
+	for _, line := range lines {
+		buf.WriteString(foldVCardLine(line))
+		buf.WriteString("\r\n")
+	}
+	return buf.String()
+}
+
+// escapeVCardText экранирует специальные символы текстовых значений vCard
+func escapeVCardText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		",", `\,`,
+		";", `\;`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// vCardStructuredName формирует свойство N (фамилия;имя;;;) из полного имени
+func vCardStructuredName(name string) string {
+	parts := strings.Fields(name)
+	switch len(parts) {
+	case 0:
+		return ";;;;"
+	case 1:
+		return escapeVCardText(parts[0]) + ";;;;"
+	}
+	family := parts[len(parts)-1]
+	given := strings.Join(parts[:len(parts)-1], " ")
+	return escapeVCardText(family) + ";" + escapeVCardText(given) + ";;;"
+}
+
+// foldVCardLine переносит длинные строки, не разрывая многобайтовые символы UTF-8
+func foldVCardLine(line string) string {
+	if len(line) <= vCardLineLimit {
+		return line
+	}
+
+	var buf strings.Builder
+	limit := vCardLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Продолжение начинается с пробела, который входит в лимит
+		limit = vCardLineLimit - 1
+	}
+	buf.WriteString(line)
+	return buf.String()
+}
+
+// hCardTemplate шаблон страницы профиля с микроразметкой h-card (microformats2)
+var hCardTemplate = template.Must(template.New("hcard").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.User.Name}}</title>
+{{if .ProfileURL}}<link rel="canonical" href="{{.ProfileURL}}">
+<meta property="og:type" content="profile">
+<meta property="og:url" content="{{.ProfileURL}}">
+<meta property="og:title" content="{{.User.Name}}">
+{{if .User.Title}}<meta property="og:description" content="{{.User.Title}}">
+{{end}}<meta property="og:image" content="{{.ProfileURL}}/card.png">
+<meta property="og:image:width" content="1200">
+<meta property="og:image:height" content="630">
+<meta name="twitter:card" content="summary_large_image">
+{{end}}</head>
+<body>
+<article class="h-card">
+{{if .User.Avatar}}  <img class="u-photo" src="{{.User.Avatar}}" alt="{{.User.Name}}">
+{{end}}  <h1>{{if .ProfileURL}}<a class="p-name u-url u-uid" href="{{.ProfileURL}}">{{.User.Name}}</a>{{else}}<span class="p-name">{{.User.Name}}</span>{{end}}</h1>
+{{if .User.Title}}  <p class="p-job-title">{{.User.Title}}</p>
+{{end}}{{if .User.Bio}}  <p class="p-note">{{.User.Bio}}</p>
+{{end}}{{if .User.Email}}  <a class="u-email" href="mailto:{{.User.Email}}">{{.User.Email}}</a>
+{{end}}{{if .Links}}  <ul>
+{{range .Links}}    <li><a class="u-url" rel="me" href="{{.URL}}">{{.Label}}</a></li>
+{{end}}  </ul>
+{{end}}{{if .User.Skills}}  <ul>
+{{range .User.Skills}}    <li class="p-category">{{.}}</li>
+{{end}}  </ul>
+{{end}}</article>
+</body>
+</html>
+`))
+
+// RenderHCard формирует HTML-страницу профиля с микроразметкой h-card
+func RenderHCard(user models.User, profileURL string) (string, error) {
+	type link struct{ Label, URL string }
+	links := []link{}
+	for _, l := range []link{
+		{"Website", user.Social.Website},
+		{"GitHub", user.Social.GitHub},
+		{"Twitter", user.Social.Twitter},
+		{"LinkedIn", user.Social.LinkedIn},
+	} {
+		if l.URL != "" {
+			links = append(links, l)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := hCardTemplate.Execute(&buf, struct {
+		User       models.User
+		ProfileURL string
+		Links      []link
+	}{user, profileURL, links})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderJSONLD формирует описание профиля в виде schema.org Person (JSON-LD)
+func RenderJSONLD(user models.User, profileURL string) map[string]interface{} {
+	person := map[string]interface{}{
+		"@context": "https://schema.org",
+		"@type":    "Person",
+		"name":     user.Name,
+	}
+	if profileURL != "" {
+		person["@id"] = profileURL
+		person["mainEntityOfPage"] = profileURL
+	}
+	if user.Title != "" {
+		person["jobTitle"] = user.Title
+	}
+	if user.Bio != "" {
+		person["description"] = user.Bio
+	}
+	if user.Avatar != "" {
+		person["image"] = absoluteURL(profileURL, user.Avatar)
+	}
+	if user.Email != "" {
+		person["email"] = "mailto:" + user.Email
+	}
+	if user.Social.Website != "" {
+		person["url"] = user.Social.Website
+	} else if profileURL != "" {
+		person["url"] = profileURL
+	}
+
+	sameAs := []string{}
+	for _, link := range []string{user.Social.GitHub, user.Social.Twitter, user.Social.LinkedIn} {
+		if link != "" {
+			sameAs = append(sameAs, link)
+		}
+	}
+	if len(sameAs) > 0 {
+		person["sameAs"] = sameAs
+	}
+	if len(user.Skills) > 0 {
+		person["knowsAbout"] = user.Skills
+	}
+	if !user.UpdatedAt.IsZero() {
+		person["dateModified"] = user.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+
+	return person
+}
+
+// absoluteURL разрешает ссылку ref (например, путь к локально сохраненному аватару) относительно
+// адреса профиля; абсолютные и некорректные ссылки возвращаются без изменений
+func absoluteURL(profileURL, ref string) string {
+	base, err := url.Parse(profileURL)
+	if err != nil || !base.IsAbs() {
+		return ref
+	}
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(parsed).String()
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"your-project/backend/models"
+)
+
+func TestProfileFormatsUseAbsoluteAvatarURL(t *testing.T) {
+	profileURL := "https://api.example.com/api/users/42"
+	tests := []struct {
+		name   string
+		avatar string
+		want   string
+	}{
+		{"local upload", "/uploads/avatars/a/original.jpg", "https://api.example.com/uploads/avatars/a/original.jpg"},
+		{"external", "https://cdn.example.com/a.jpg", "https://cdn.example.com/a.jpg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := models.User{Name: "Ada Lovelace", Avatar: tt.avatar}
+
+			if vcard := RenderVCard(user, profileURL); !strings.Contains(vcard, "PHOTO:"+tt.want+"\r\n") {
+				t.Errorf("vCard has no PHOTO:%s\n%s", tt.want, vcard)
+			}
+			if image := RenderJSONLD(user, profileURL)["image"]; image != tt.want {
+				t.Errorf("JSON-LD image = %v, want %s", image, tt.want)
+			}
+		})
+	}
+}
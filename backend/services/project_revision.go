@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// revisionAttempts количество попыток сохранить версию при параллельных изменениях проекта
+const revisionAttempts = 3
+
+// ErrRevisionNotFound возвращается, если у проекта нет версии с таким номером
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// GetRevisions возвращает историю версий проекта, начиная с новых
+func (s *ProjectService) GetRevisions(ctx context.Context, id string) ([]models.ProjectRevision, error) {
+	projectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.revisionRepo.FindByProjectID(ctx, projectID)
+}
+
+// GetRevision возвращает версию проекта по номеру
+func (s *ProjectService) GetRevision(ctx context.Context, id string, number int) (models.ProjectRevision, error) {
+	projectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return models.ProjectRevision{}, ErrRevisionNotFound
+	}
+
+	revision, err := s.revisionRepo.FindByNumber(ctx, projectID, number)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.ProjectRevision{}, ErrRevisionNotFound
+	}
+	return revision, err
+}
+
+// DiffRevisions возвращает изменения полей между версиями from и to. Если to не задан (0),
+// используется последняя версия, если не задан from - версия, предшествующая to.
+func (s *ProjectService) DiffRevisions(ctx context.Context, id string, from, to int) (models.RevisionDiff, error) {
+	if to == 0 {
+		projectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return models.RevisionDiff{}, ErrRevisionNotFound
+		}
+		latest, err := s.revisionRepo.FindLatest(ctx, projectID)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.RevisionDiff{}, ErrRevisionNotFound
+		}
+		if err != nil {
+			return models.RevisionDiff{}, err
+		}
+		to = latest.Number
+	}
+	if from == 0 {
+		from = to - 1
+	}
+
+	toRevision, err := s.GetRevision(ctx, id, to)
+	if err != nil {
+		return models.RevisionDiff{}, err
+	}
+	fromRevision, err := s.GetRevision(ctx, id, from)
+	if err != nil {
+		return models.RevisionDiff{}, err
+	}
+
+	return models.RevisionDiff{
+		From:    from,
+		To:      to,
+		Changes: diffFields(snapshotPairs(fromRevision.Snapshot, toRevision.Snapshot)),
+	}, nil
+}
+
+// RestoreRevision возвращает содержимое проекта к версии number и сохраняет это как новую версию
+func (s *ProjectService) RestoreRevision(ctx context.Context, id string, number int, editorID string) (models.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Project{}, err
+	}
+	revision, err := s.GetRevision(ctx, id, number)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	restored := project
+	applySnapshot(&restored, revision.Snapshot)
+	if err := prepareProjectStatus(&restored); err != nil {
+		return models.Project{}, err
+	}
+	// Категория могла быть удалена после сохранения версии
+	if err := s.prepareProjectClassification(ctx, &restored); errors.Is(err, ErrUnknownCategory) {
+		restored.Category = ""
+	} else if err != nil {
+		return models.Project{}, err
+	}
+
+	if err := renderProjectDescription(&restored); err != nil {
+		return models.Project{}, err
+	}
+	if err := prepareCaseStudy(&restored); err != nil {
+		return models.Project{}, err
+	}
+
+	snapshot := snapshotOf(restored)
+	set := map[string]interface{}{
+		"title":            snapshot.Title,
+		"description":      snapshot.Description,
+		"description_html": restored.DescriptionHTML,
+		"technologies":     snapshot.Technologies,
+		"category":         snapshot.Category,
+		"tags":             snapshot.Tags,
+		"case_study":       restored.CaseStudy,
+		"github_url":       snapshot.GitHubURL,
+		"live_url":         snapshot.LiveURL,
+		"status":           snapshot.Status,
+	}
+	var unset []string
+	if snapshot.PublishAt != nil {
+		set["publish_at"] = snapshot.PublishAt
+	} else {
+		unset = append(unset, "publish_at")
+	}
+	if err := s.projectRepo.Patch(ctx, id, set, unset); err != nil {
+		return models.Project{}, err
+	}
+
+	updated, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Project{}, err
+	}
+	if err := s.recordRevision(ctx, &project, updated, editorID, models.RevisionRestore, number); err != nil {
+		return models.Project{}, err
+	}
+	return updated, nil
+}
+
+// recordRevision сохраняет новую версию проекта after, если его содержимое изменилось.
+// before - состояние до изменения; если история проекта еще пуста, оно сохраняется как
+// начальная версия (например, для проектов, созданных импортом резюме).
+func (s *ProjectService) recordRevision(ctx context.Context, before *models.Project, after models.Project, editorID, action string, restoredFrom int) error {
+	revision := models.ProjectRevision{
+		ProjectID:    after.ID,
+		Action:       action,
+		RestoredFrom: restoredFrom,
+		Snapshot:     snapshotOf(after),
+	}
+	if editor, err := s.userRepo.FindByID(ctx, editorID); err == nil {
+		revision.AuthorID = &editor.ID
+		revision.AuthorName = editor.Name
+	} else if !errors.Is(err, mongo.ErrNoDocuments) && !errors.Is(err, primitive.ErrInvalidHex) {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := s.appendRevision(ctx, before, revision)
+		// Номер версии занят параллельным изменением: повторяем с новым номером
+		if !errors.Is(err, repositories.ErrRevisionExists) || attempt == revisionAttempts {
+			return err
+		}
+	}
+}
+
+// appendRevision сохраняет revision следующим номером после последней версии проекта
+func (s *ProjectService) appendRevision(ctx context.Context, before *models.Project, revision models.ProjectRevision) error {
+	latest, err := s.revisionRepo.FindLatest(ctx, revision.ProjectID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Для первой версии изменившимися считаются все заполненные поля
+		latest = models.ProjectRevision{Snapshot: snapshotOf(models.Project{})}
+		if before != nil {
+			latest, err = s.revisionRepo.Create(ctx, models.ProjectRevision{
+				ProjectID:     revision.ProjectID,
+				Number:        1,
+				Action:        models.RevisionInitial,
+				ChangedFields: []string{},
+				Snapshot:      snapshotOf(*before),
+				CreatedAt:     before.UpdatedAt,
+			})
+		} else {
+			err = nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	revision.ChangedFields = []string{}
+	for _, change := range diffFields(snapshotPairs(latest.Snapshot, revision.Snapshot)) {
+		revision.ChangedFields = append(revision.ChangedFields, change.Field)
+	}
+	if latest.Number > 0 && len(revision.ChangedFields) == 0 {
+		return nil
+	}
+
+	revision.Number = latest.Number + 1
+	_, err = s.revisionRepo.Create(ctx, revision)
+	return err
+}
+
+// snapshotOf возвращает редактируемое содержимое проекта
+func snapshotOf(project models.Project) models.ProjectSnapshot {
+	snapshot := models.ProjectSnapshot{
+		Title:        project.Title,
+		Description:  project.Description,
+		Technologies: project.Technologies,
+		Category:     project.Category,
+		Tags:         project.Tags,
+		CaseStudy:    make([]models.CaseStudySection, len(project.CaseStudy)),
+		GitHubURL:    project.GitHubURL,
+		LiveURL:      project.LiveURL,
+		Status:       project.Status,
+	}
+	// Пустые списки и отсутствующие значения не считаются изменением
+	if snapshot.Technologies == nil {
+		snapshot.Technologies = []string{}
+	}
+	if snapshot.Tags == nil {
+		snapshot.Tags = []string{}
+	}
+	for i, section := range project.CaseStudy {
+		section.ContentHTML = ""
+		snapshot.CaseStudy[i] = section
+	}
+	if snapshot.Status == "" {
+		snapshot.Status = models.ProjectPublished
+	}
+	if project.PublishAt != nil {
+		// MongoDB хранит время с точностью до миллисекунд
+		publishAt := project.PublishAt.UTC().Truncate(time.Millisecond)
+		snapshot.PublishAt = &publishAt
+	}
+	return snapshot
+}
+
+// applySnapshot записывает содержимое версии в проект
+func applySnapshot(project *models.Project, snapshot models.ProjectSnapshot) {
+	project.Title = snapshot.Title
+	project.Description = snapshot.Description
+	project.Technologies = snapshot.Technologies
+	project.Category = snapshot.Category
+	project.Tags = snapshot.Tags
+	project.CaseStudy = snapshot.CaseStudy
+	project.GitHubURL = snapshot.GitHubURL
+	project.LiveURL = snapshot.LiveURL
+	project.Status = snapshot.Status
+	project.PublishAt = snapshot.PublishAt
+}
+
+// snapshotPairs возвращает значения полей двух версий для сравнения
+func snapshotPairs(old, new models.ProjectSnapshot) []fieldPair {
+	// Версии, сохраненные до появления подробного описания, не содержат его разделов
+	if old.CaseStudy == nil {
+		old.CaseStudy = []models.CaseStudySection{}
+	}
+	if new.CaseStudy == nil {
+		new.CaseStudy = []models.CaseStudySection{}
+	}
+	return []fieldPair{
+		{"title", old.Title, new.Title},
+		{"description", old.Description, new.Description},
+		{"technologies", old.Technologies, new.Technologies},
+		{"category", old.Category, new.Category},
+		{"tags", old.Tags, new.Tags},
+		{"caseStudy", old.CaseStudy, new.CaseStudy},
+		{"githubUrl", old.GitHubURL, new.GitHubURL},
+		{"liveUrl", old.LiveURL, new.LiveURL},
+		{"status", old.Status, new.Status},
+		{"publishAt", old.PublishAt, new.PublishAt},
+	}
+}
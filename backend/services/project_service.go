@@ -3,28 +3,140 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"your-project/backend/models"
 	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// projectReadOnlyFields поля проекта, которые нельзя изменить через PATCH
+var projectReadOnlyFields = map[string]bool{
+	"id":              true,
+	"descriptionHtml": true, // формируется из description
+	"imageThumbnails": true,
+	"gallery":         true, // галерея и обложка меняются через отдельные эндпоинты
+	"coverMediaId":    true,
+	"github":          true, // заполняется синхронизацией с GitHub
+	"starCount":       true,
+	"commentCount":    true,
+	"userId":          true,
+	"contributors":    true, // участники меняются через приглашения
+	"userName":        true,
+	"userAvatar":      true,
+	"createdAt":       true,
+	"updatedAt":       true,
+}
+
+const (
+	// ScheduledPublishInterval период проверки черновиков с наступившим временем публикации
+	ScheduledPublishInterval = time.Minute
+	// MaxPinnedProjects максимальное количество закрепленных проектов в профиле
+	MaxPinnedProjects = 6
+	// MaxProjectTags максимальное количество тегов проекта
+	MaxProjectTags = 10
+	// MaxProjectTagLength максимальная длина тега в символах
+	MaxProjectTagLength = 30
+	// DefaultBrowsePageSize размер страницы каталога проектов по умолчанию
+	DefaultBrowsePageSize = 20
+	// MaxBrowsePageSize максимальный размер страницы каталога проектов
+	MaxBrowsePageSize = 100
+	// browseFacetLimit максимальное количество значений в одном фасете
+	browseFacetLimit = 50
+)
+
+var (
+	// ErrInvalidProjectStatus возвращается для неизвестного статуса проекта
+	ErrInvalidProjectStatus = errors.New("status must be draft, published or archived")
+	// ErrPinLimitReached возвращается при попытке закрепить больше MaxPinnedProjects проектов
+	ErrPinLimitReached = fmt.Errorf("you can pin at most %d projects", MaxPinnedProjects)
+	// ErrProjectNotOnProfile возвращается, если проект не относится к профилю пользователя
+	ErrProjectNotOnProfile = errors.New("project is not on this profile")
+	// ErrInvalidProjectOrder возвращается для порядка с повторяющимися проектами
+	ErrInvalidProjectOrder = errors.New("project order must not contain duplicates")
+	// ErrUnknownCategory возвращается для проекта с несуществующей категорией
+	ErrUnknownCategory = errors.New("unknown project category")
+	// ErrInvalidTags возвращается для слишком большого количества тегов или слишком длинного тега
+	ErrInvalidTags = fmt.Errorf("a project can have at most %d tags of up to %d characters", MaxProjectTags, MaxProjectTagLength)
 )
 
 // ProjectService представляет сервис для работы с проектами
 type ProjectService struct {
-	projectRepo *repositories.ProjectRepository
-	userRepo    *repositories.UserRepository
+	projectRepo    *repositories.ProjectRepository
+	userRepo       *repositories.UserRepository
+	categoryRepo   *repositories.CategoryRepository
+	revisionRepo   *repositories.RevisionRepository
+	collectionRepo *repositories.CollectionRepository
+	starRepo       *repositories.StarRepository
+	commentRepo    *repositories.CommentRepository
+	imageService   *ImageService
 }
 
 // NewProjectService создает новый сервис проектов
-func NewProjectService(projectRepo *repositories.ProjectRepository, userRepo *repositories.UserRepository) *ProjectService {
+func NewProjectService(projectRepo *repositories.ProjectRepository, userRepo *repositories.UserRepository, categoryRepo *repositories.CategoryRepository, revisionRepo *repositories.RevisionRepository, collectionRepo *repositories.CollectionRepository, starRepo *repositories.StarRepository, commentRepo *repositories.CommentRepository, imageService *ImageService) *ProjectService {
 	return &ProjectService{
-		projectRepo: projectRepo,
-		userRepo:    userRepo,
+		projectRepo:    projectRepo,
+		userRepo:       userRepo,
+		categoryRepo:   categoryRepo,
+		revisionRepo:   revisionRepo,
+		collectionRepo: collectionRepo,
+		starRepo:       starRepo,
+		commentRepo:    commentRepo,
+		imageService:   imageService,
 	}
 }
 
-// GetAllProjects возвращает все проекты
+// GetAllProjects возвращает все опубликованные проекты
 func (s *ProjectService) GetAllProjects(ctx context.Context) ([]models.Project, error) {
-	return s.projectRepo.FindAll(ctx)
+	return s.projectRepo.FindPublished(ctx)
+}
+
+// GetProjectsByStars возвращает опубликованные проекты, начиная с самых отмеченных
+func (s *ProjectService) GetProjectsByStars(ctx context.Context) ([]models.Project, error) {
+	return s.projectRepo.FindPublishedByStars(ctx)
+}
+
+// BrowseProjects возвращает страницу каталога опубликованных проектов с фасетами для фильтров
+func (s *ProjectService) BrowseProjects(ctx context.Context, filter models.ProjectFilter, page, limit int) (models.ProjectBrowsePage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultBrowsePageSize
+	}
+	if limit > MaxBrowsePageSize {
+		limit = MaxBrowsePageSize
+	}
+	filter.Tags = normalizeTags(filter.Tags)
+
+	result, err := s.projectRepo.Browse(ctx, filter, (page-1)*limit, limit, browseFacetLimit)
+	if err != nil {
+		return models.ProjectBrowsePage{}, err
+	}
+
+	result.Page = page
+	result.Limit = limit
+	if result.Projects == nil {
+		result.Projects = []models.Project{}
+	}
+	if result.Facets.Categories == nil {
+		result.Facets.Categories = []models.FacetCount{}
+	}
+	if result.Facets.Tags == nil {
+		result.Facets.Tags = []models.FacetCount{}
+	}
+	if result.Facets.Technologies == nil {
+		result.Facets.Technologies = []models.FacetCount{}
+	}
+	return result, nil
 }
 
 // GetProjectByID возвращает проект по ID
@@ -42,12 +154,51 @@ func (s *ProjectService) CreateProject(ctx context.Context, project models.Proje
 
 	project.UserName = user.Name
 	project.UserAvatar = user.Avatar
+	project.StarCount = 0
+	project.CommentCount = 0
+	// Галерея, обложка, участники и данные GitHub заполняются только через отдельные эндпоинты
+	project.Gallery = nil
+	project.CoverMediaID = nil
+	project.GitHub = nil
+	project.Contributors = nil
+
+	// Без статуса проект публикуется сразу, а при заданном publishAt остается черновиком до этого времени
+	if project.Status == "" {
+		project.Status = models.ProjectPublished
+		if project.PublishAt != nil {
+			project.Status = models.ProjectDraft
+		}
+	}
+	if err := prepareProjectStatus(&project); err != nil {
+		return project, err
+	}
+	if err := s.prepareProjectClassification(ctx, &project); err != nil {
+		return project, err
+	}
+	if err := renderProjectDescription(&project); err != nil {
+		return project, err
+	}
+	if err := prepareCaseStudy(&project); err != nil {
+		return project, err
+	}
 
-	return s.projectRepo.Create(ctx, project)
+	created, err := s.projectRepo.Create(ctx, project)
+	if err != nil {
+		return created, err
+	}
+	if err := s.recordRevision(ctx, nil, created, user.ID.Hex(), models.RevisionCreate, 0); err != nil {
+		return created, err
+	}
+	return created, nil
 }
 
-// UpdateProject обновляет проект
-func (s *ProjectService) UpdateProject(ctx context.Context, id string, project models.Project) error {
+// UpdateProject обновляет проект; editorID - пользователь, вносящий изменения
+func (s *ProjectService) UpdateProject(ctx context.Context, id string, project models.Project, editorID string) error {
+	existing, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	// Обновить информацию о пользователе в проекте
 	user, err := s.userRepo.FindByID(ctx, project.UserID.Hex())
 	if err != nil {
@@ -57,17 +208,540 @@ func (s *ProjectService) UpdateProject(ctx context.Context, id string, project m
 	project.UserName = user.Name
 	project.UserAvatar = user.Avatar
 
-	return s.projectRepo.Update(ctx, id, project)
+	// Галерея, обложка, участники и данные GitHub меняются только через отдельные эндпоинты
+	project.Gallery = nil
+	project.CoverMediaID = nil
+	project.GitHub = nil
+	project.Contributors = nil
+	// Счетчики не попадают в $set, они меняются только атомарно
+	project.StarCount = 0
+	project.CommentCount = 0
+
+	// Пустой статус не попадает в $set, т.е. статус остается прежним
+	if err := prepareProjectStatus(&project); err != nil {
+		return err
+	}
+	if err := s.prepareProjectClassification(ctx, &project); err != nil {
+		return err
+	}
+	if err := renderProjectDescription(&project); err != nil {
+		return err
+	}
+	if err := prepareCaseStudy(&project); err != nil {
+		return err
+	}
+
+	if err := s.projectRepo.Update(ctx, id, project); err != nil {
+		return err
+	}
+
+	var unset []string
+	// Плановая публикация снимается при публикации или архивации
+	if project.Status == models.ProjectPublished || project.Status == models.ProjectArchived {
+		unset = append(unset, "publish_at")
+	}
+	// Данные GitHub относятся к прежнему репозиторию и будут получены заново при синхронизации
+	if project.GitHubURL != existing.GitHubURL {
+		unset = append(unset, "github")
+	}
+	if len(unset) > 0 {
+		if err := s.projectRepo.Patch(ctx, id, nil, unset); err != nil {
+			return err
+		}
+	}
+
+	updated, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.recordRevision(ctx, &existing, updated, editorID, models.RevisionUpdate, 0)
+}
+
+// PatchProject частично обновляет проект по JSON Merge Patch (RFC 7396); editorID - пользователь,
+// вносящий изменения
+func (s *ProjectService) PatchProject(ctx context.Context, id string, patch []byte, editorID string) (models.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	var patched models.Project
+	update, err := buildMergePatchUpdate(project, patch, projectReadOnlyFields, &patched)
+	if err != nil {
+		return models.Project{}, err
+	}
+	// Проект без статуса считается опубликованным, поэтому статус можно только заменить, но не снять
+	_, statusSet := update.Set["status"]
+	if patched.Status == "" && (statusSet || containsString(update.Unset, "status")) {
+		return models.Project{}, fmt.Errorf("%w: %v", ErrInvalidPatch, ErrInvalidProjectStatus)
+	}
+	if err := prepareProjectStatus(&patched); err != nil {
+		return models.Project{}, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	if err := s.prepareProjectClassification(ctx, &patched); err != nil {
+		return models.Project{}, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	// Нормализованные категория и теги заменяют значения из патча
+	if _, ok := update.Set["category"]; ok {
+		update.Set["category"] = patched.Category
+	}
+	if _, ok := update.Set["tags"]; ok {
+		update.Set["tags"] = patched.Tags
+	}
+	if err := renderProjectDescription(&patched); err != nil {
+		return models.Project{}, err
+	}
+	renderPatchedMarkdown(&update, "description", "description_html", patched.DescriptionHTML)
+	if err := prepareCaseStudy(&patched); err != nil {
+		return models.Project{}, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	// Разделы подробного описания сохраняются нормализованными и с HTML
+	if _, ok := update.Set["case_study"]; ok {
+		update.Set["case_study"] = patched.CaseStudy
+	}
+	if patched.PublishAt == nil && project.PublishAt != nil {
+		// Плановая публикация снята патчем или сменой статуса
+		delete(update.Set, "publish_at")
+		if !containsString(update.Unset, "publish_at") {
+			update.Unset = append(update.Unset, "publish_at")
+		}
+	}
+	if patched.GitHubURL != project.GitHubURL {
+		// Данные GitHub относятся к прежнему репозиторию и будут получены заново при синхронизации
+		update.Unset = append(update.Unset, "github")
+	}
+	if update.IsEmpty() {
+		return project, nil
+	}
+
+	if err := s.projectRepo.Patch(ctx, id, update.Set, update.Unset); err != nil {
+		return models.Project{}, err
+	}
+
+	updated, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Project{}, err
+	}
+	if err := s.recordRevision(ctx, &project, updated, editorID, models.RevisionUpdate, 0); err != nil {
+		return models.Project{}, err
+	}
+	return updated, nil
+}
+
+// RenderMissingDescriptions сохраняет HTML описаний проектов, созданных до появления Markdown,
+// и возвращает количество обновленных проектов
+func (s *ProjectService) RenderMissingDescriptions(ctx context.Context) (int, error) {
+	projects, err := s.projectRepo.FindWithoutDescriptionHTML(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, project := range projects {
+		if err := renderProjectDescription(&project); err != nil {
+			return i, err
+		}
+		if err := s.projectRepo.SetDescriptionHTML(ctx, project.ID, project.DescriptionHTML); err != nil {
+			return i, err
+		}
+	}
+	return len(projects), nil
+}
+
+// UploadProjectImage обрабатывает загруженное изображение и устанавливает его изображением проекта
+func (s *ProjectService) UploadProjectImage(ctx context.Context, id string, data []byte) (models.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	stored, err := s.imageService.StoreImage(ctx, "projects/"+id, data, ProjectImageVariants)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	if err := s.projectRepo.UpdateImage(ctx, id, stored.URL, stored.Thumbnails); err != nil {
+		return models.Project{}, err
+	}
+
+	// Обложка из галереи остается элементом галереи, ее файлы не удаляются
+	if project.Image != "" && !isGalleryImage(project, project.Image) {
+		previous := StoredImage{URL: project.Image, Thumbnails: project.ImageThumbnails}
+		if err := s.imageService.DeleteImage(ctx, previous); err != nil {
+			log.Printf("Failed to delete previous image of project %s: %v", id, err)
+		}
+	}
+
+	project.Image = stored.URL
+	project.ImageThumbnails = stored.Thumbnails
+	project.CoverMediaID = nil
+	return project, nil
+}
+
+// isGalleryImage сообщает, принадлежит ли изображение url одному из элементов галереи проекта
+func isGalleryImage(project models.Project, url string) bool {
+	for _, item := range project.Gallery {
+		if item.URL == url {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareProjectStatus проверяет статус проекта; плановая публикация сохраняется только у черновиков
+func prepareProjectStatus(project *models.Project) error {
+	switch project.Status {
+	case "", models.ProjectDraft:
+	case models.ProjectPublished, models.ProjectArchived:
+		project.PublishAt = nil
+	default:
+		return ErrInvalidProjectStatus
+	}
+	return nil
+}
+
+// prepareProjectClassification нормализует теги и проверяет, что категория существует
+func (s *ProjectService) prepareProjectClassification(ctx context.Context, project *models.Project) error {
+	project.Tags = normalizeTags(project.Tags)
+	if len(project.Tags) > MaxProjectTags {
+		return ErrInvalidTags
+	}
+	for _, tag := range project.Tags {
+		if utf8.RuneCountInString(tag) > MaxProjectTagLength {
+			return ErrInvalidTags
+		}
+	}
+
+	project.Category = strings.ToLower(strings.TrimSpace(project.Category))
+	if project.Category == "" {
+		return nil
+	}
+	if _, err := s.categoryRepo.FindBySlug(ctx, project.Category); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrUnknownCategory
+		}
+		return err
+	}
+	return nil
+}
+
+// normalizeTags приводит теги к нижнему регистру и удаляет пустые и повторяющиеся
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+		if tag != "" && !containsString(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// profileProject проверяет, что проект относится к профилю пользователя, и возвращает
+// его ID вместе с ID всех проектов профиля
+func (s *ProjectService) profileProject(ctx context.Context, userID, projectID string) (primitive.ObjectID, []primitive.ObjectID, error) {
+	projectObjectID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return primitive.NilObjectID, nil, ErrProjectNotOnProfile
+	}
+
+	projects, err := s.projectRepo.FindByMember(ctx, userID)
+	if err != nil {
+		return primitive.NilObjectID, nil, err
+	}
+
+	memberIDs := make([]primitive.ObjectID, 0, len(projects))
+	found := false
+	for _, project := range projects {
+		memberIDs = append(memberIDs, project.ID)
+		if project.ID == projectObjectID {
+			found = true
+		}
+	}
+	if !found {
+		return primitive.NilObjectID, nil, ErrProjectNotOnProfile
+	}
+	return projectObjectID, memberIDs, nil
+}
+
+// sortProfileProjects упорядочивает проекты профиля: сначала закрепленные в порядке закрепления,
+// затем по заданному пользователем порядку, остальные - начиная с новых
+func sortProfileProjects(projects []models.Project, user models.User) {
+	rank := func(ids []primitive.ObjectID, id primitive.ObjectID) int {
+		if index := indexOfObjectID(ids, id); index >= 0 {
+			return index
+		}
+		return len(ids)
+	}
+
+	for i := range projects {
+		projects[i].Pinned = indexOfObjectID(user.PinnedProjects, projects[i].ID) >= 0
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		a, b := projects[i], projects[j]
+		if pinA, pinB := rank(user.PinnedProjects, a.ID), rank(user.PinnedProjects, b.ID); pinA != pinB {
+			return pinA < pinB
+		}
+		if orderA, orderB := rank(user.ProjectOrder, a.ID), rank(user.ProjectOrder, b.ID); orderA != orderB {
+			return orderA < orderB
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+}
+
+// indexOfObjectID возвращает позицию id в ids или -1
+func indexOfObjectID(ids []primitive.ObjectID, id primitive.ObjectID) int {
+	for i, candidate := range ids {
+		if candidate == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// onlyPublished оставляет только опубликованные проекты
+func onlyPublished(projects []models.Project) []models.Project {
+	published := make([]models.Project, 0, len(projects))
+	for _, project := range projects {
+		if project.IsPublished() {
+			published = append(published, project)
+		}
+	}
+	return published
 }
 
-// DeleteProject удаляет проект
+// DeleteProject удаляет проект вместе с его отметками, комментариями, историей версий и упоминаниями в коллекциях
 func (s *ProjectService) DeleteProject(ctx context.Context, id string) error {
-	return s.projectRepo.Delete(ctx, id)
+	project, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.projectRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.deleteProjectFiles(ctx, project)
+
+	if err := s.collectionRepo.RemoveProjectEverywhere(ctx, project.ID); err != nil {
+		return err
+	}
+	if err := s.starRepo.DeleteByProjectID(ctx, project.ID); err != nil {
+		return err
+	}
+	if err := s.commentRepo.DeleteByProjectID(ctx, project.ID); err != nil {
+		return err
+	}
+	return s.revisionRepo.DeleteByProjectID(ctx, project.ID)
+}
+
+// deleteProjectFiles удаляет из хранилища обложку и изображения галереи удаленного проекта.
+// Проект уже удален, поэтому ошибки хранилища только записываются в лог.
+func (s *ProjectService) deleteProjectFiles(ctx context.Context, project models.Project) {
+	for _, item := range project.Gallery {
+		if item.Type != models.MediaTypeImage {
+			continue
+		}
+		if err := s.imageService.DeleteImage(ctx, StoredImage{URL: item.URL, Thumbnails: item.Thumbnails}); err != nil {
+			log.Printf("Failed to delete files of media item %s: %v", item.ID.Hex(), err)
+		}
+	}
+
+	// Обложка из галереи уже удалена вместе с элементом
+	if project.Image != "" && !isGalleryImage(project, project.Image) {
+		cover := StoredImage{URL: project.Image, Thumbnails: project.ImageThumbnails}
+		if err := s.imageService.DeleteImage(ctx, cover); err != nil {
+			log.Printf("Failed to delete image of project %s: %v", project.ID.Hex(), err)
+		}
+	}
+}
+
+// GetUserProjects возвращает проекты пользователя, включая проекты, в которых он участвует.
+// Черновики и архивные проекты видны только их участникам.
+func (s *ProjectService) GetUserProjects(ctx context.Context, userID, viewerID string) ([]models.Project, error) {
+	projects, err := s.projectRepo.FindByMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	visible := make([]models.Project, 0, len(projects))
+	for _, project := range projects {
+		if project.IsVisibleTo(viewerID) {
+			visible = append(visible, project)
+		}
+	}
+	sortProfileProjects(visible, user)
+	return visible, nil
+}
+
+// PinProject закрепляет проект в профиле пользователя и возвращает его проекты в новом порядке
+func (s *ProjectService) PinProject(ctx context.Context, userID, projectID string) ([]models.Project, error) {
+	projectObjectID, memberIDs, err := s.profileProject(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if indexOfObjectID(user.PinnedProjects, projectObjectID) < 0 {
+		pinned, err := s.userRepo.PinProject(ctx, userID, projectObjectID, memberIDs, MaxPinnedProjects)
+		if err != nil {
+			return nil, err
+		}
+		if !pinned {
+			return nil, ErrPinLimitReached
+		}
+	}
+
+	return s.GetUserProjects(ctx, userID, userID)
+}
+
+// UnpinProject снимает закрепление проекта и возвращает проекты пользователя в новом порядке
+func (s *ProjectService) UnpinProject(ctx context.Context, userID, projectID string) ([]models.Project, error) {
+	projectObjectID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return nil, ErrProjectNotOnProfile
+	}
+
+	if err := s.userRepo.UnpinProject(ctx, userID, projectObjectID); err != nil {
+		return nil, err
+	}
+
+	return s.GetUserProjects(ctx, userID, userID)
+}
+
+// ReorderProjects задает порядок проектов в профиле. Проекты, не указанные в списке,
+// выводятся после упорядоченных, начиная с новых.
+func (s *ProjectService) ReorderProjects(ctx context.Context, userID string, projectIDs []string) ([]models.Project, error) {
+	projects, err := s.projectRepo.FindByMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	onProfile := make(map[primitive.ObjectID]bool, len(projects))
+	for _, project := range projects {
+		onProfile[project.ID] = true
+	}
+
+	order := make([]primitive.ObjectID, 0, len(projectIDs))
+	seen := make(map[primitive.ObjectID]bool, len(projectIDs))
+	for _, id := range projectIDs {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil || !onProfile[objectID] {
+			return nil, ErrProjectNotOnProfile
+		}
+		if seen[objectID] {
+			return nil, ErrInvalidProjectOrder
+		}
+		seen[objectID] = true
+		order = append(order, objectID)
+	}
+
+	if err := s.userRepo.SetProjectOrder(ctx, userID, order); err != nil {
+		return nil, err
+	}
+
+	return s.GetUserProjects(ctx, userID, userID)
+}
+
+// PublishScheduled публикует черновики, время плановой публикации которых наступило,
+// и сохраняет для каждого версию без автора
+func (s *ProjectService) PublishScheduled(ctx context.Context) (int64, error) {
+	now := time.Now()
+	projects, err := s.projectRepo.FindPublishDue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var published int64
+	for _, project := range projects {
+		ok, err := s.projectRepo.PublishDue(ctx, project.ID, now)
+		if err != nil {
+			return published, err
+		}
+		// Проект изменили между поиском и публикацией
+		if !ok {
+			continue
+		}
+		published++
+
+		updated := project
+		updated.Status = models.ProjectPublished
+		updated.PublishAt = nil
+		updated.UpdatedAt = now
+		if err := s.recordRevision(ctx, &project, updated, "", models.RevisionPublish, 0); err != nil {
+			log.Printf("Failed to record revision for published project %s: %v", project.ID.Hex(), err)
+		}
+	}
+	return published, nil
+}
+
+// RunScheduledPublishing периодически публикует запланированные черновики, пока не будет отменен ctx
+func (s *ProjectService) RunScheduledPublishing(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if published, err := s.PublishScheduled(ctx); err != nil {
+			log.Printf("Scheduled publishing failed: %v", err)
+		} else if published > 0 {
+			log.Printf("Published %d scheduled projects", published)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
-// GetUserProjects возвращает проекты пользователя
-func (s *ProjectService) GetUserProjects(ctx context.Context, userID string) ([]models.Project, error) {
-	return s.projectRepo.FindByUserID(ctx, userID)
+// TransferOwnership передает проект участнику newOwnerID, принявшему приглашение.
+// Прежний владелец остается в проекте мейнтейнером.
+func (s *ProjectService) TransferOwnership(ctx context.Context, id string, newOwnerID string) (models.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Project{}, err
+	}
+	if project.RoleOf(newOwnerID) == "" || project.RoleOf(newOwnerID) == models.RoleOwner {
+		return models.Project{}, ErrContributorNotFound
+	}
+
+	newOwner, err := s.userRepo.FindByID(ctx, newOwnerID)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	now := time.Now()
+	previousOwner := models.Contributor{
+		UserID:     project.UserID,
+		UserName:   project.UserName,
+		UserAvatar: project.UserAvatar,
+		Role:       models.RoleMaintainer,
+		Status:     models.ContributorAccepted,
+		InvitedBy:  newOwner.ID,
+		InvitedAt:  now,
+		AcceptedAt: &now,
+	}
+
+	transferred, err := s.projectRepo.TransferOwnership(ctx, id, newOwner, previousOwner)
+	if err != nil {
+		return models.Project{}, err
+	}
+	if !transferred {
+		return models.Project{}, ErrContributorNotFound
+	}
+
+	return s.projectRepo.FindByID(ctx, id)
 }
 
 // SearchProjects ищет проекты по заголовку или технологиям
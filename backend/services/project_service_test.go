@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+	"your-project/backend/storage"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// newTestProjectService создает сервис проектов поверх имитации MongoDB
+func newTestProjectService(mt *mtest.T) *ProjectService {
+	return NewProjectService(
+		repositories.NewProjectRepository(mt.Client, "test"),
+		repositories.NewUserRepository(mt.Client, "test"),
+		repositories.NewCategoryRepository(mt.Client, "test"),
+		repositories.NewRevisionRepository(mt.Client, "test"),
+		repositories.NewCollectionRepository(mt.Client, "test"),
+		repositories.NewStarRepository(mt.Client, "test"),
+		repositories.NewCommentRepository(mt.Client, "test"),
+		nil,
+	)
+}
+
+func TestPublishScheduledRecordsRevision(t *testing.T) {
+	newMockDB(t, "publish scheduled", func(mt *mtest.T) {
+		service := newTestProjectService(mt)
+		publishAt := time.Now().Add(-time.Minute)
+		projects := mockDocuments(mt.T,
+			models.Project{ID: primitive.NewObjectID(), Title: "due", Status: models.ProjectDraft, PublishAt: &publishAt},
+			// Публикацию перенесли между поиском и обновлением
+			models.Project{ID: primitive.NewObjectID(), Title: "moved", Status: models.ProjectDraft, PublishAt: &publishAt},
+		)
+
+		mt.AddMockResponses(
+			findResponse("test.projects", projects...),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			// Истории еще нет: сначала сохраняется начальное состояние, затем публикация
+			findResponse("test.project_revisions"),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}),
+		)
+
+		published, err := service.PublishScheduled(context.Background())
+		if err != nil || published != 1 {
+			mt.Fatalf("published = %d, err = %v, want 1", published, err)
+		}
+
+		var actions []string
+		for _, event := range mt.GetAllStartedEvents() {
+			if event.CommandName != "insert" {
+				continue
+			}
+			document := event.Command.Lookup("documents").Array().Index(0).Value().Document()
+			actions = append(actions, document.Lookup("action").StringValue())
+			if document.Lookup("action").StringValue() != models.RevisionPublish {
+				continue
+			}
+			if _, err := document.LookupErr("author_id"); err == nil {
+				mt.Error("publish revision has an author")
+			}
+			if status := document.Lookup("snapshot", "status").StringValue(); status != models.ProjectPublished {
+				mt.Errorf("snapshot status = %q, want %q", status, models.ProjectPublished)
+			}
+		}
+		if len(actions) != 2 || actions[0] != models.RevisionInitial || actions[1] != models.RevisionPublish {
+			mt.Fatalf("revision actions = %v, want [initial publish]", actions)
+		}
+	})
+}
+
+func TestPatchProjectClearsGitHubStatsOnURLChange(t *testing.T) {
+	tests := []struct {
+		name      string
+		patch     string
+		wantUnset bool
+	}{
+		{"new repository", `{"githubUrl": "https://github.com/octo/new"}`, true},
+		{"removed repository", `{"githubUrl": null}`, true},
+		{"same repository", `{"title": "Renamed", "githubUrl": "https://github.com/octo/old"}`, false},
+	}
+	for _, tt := range tests {
+		newMockDB(t, tt.name, func(mt *mtest.T) {
+			service := newTestProjectService(mt)
+			project := models.Project{
+				ID:        primitive.NewObjectID(),
+				UserID:    primitive.NewObjectID(),
+				Title:     "App",
+				GitHubURL: "https://github.com/octo/old",
+				Status:    models.ProjectPublished,
+				GitHub:    &models.GitHubStats{Stars: 10},
+			}
+			documents := mockDocuments(mt.T, project)
+			mt.AddMockResponses(
+				findResponse("test.projects", documents...),
+				mtest.CreateSuccessResponse(),
+				findResponse("test.projects", documents...),
+				findResponse("test.users"),
+				findResponse("test.project_revisions"),
+				mtest.CreateSuccessResponse(),
+				mtest.CreateSuccessResponse(),
+			)
+
+			if _, err := service.PatchProject(context.Background(), project.ID.Hex(), []byte(tt.patch), project.UserID.Hex()); err != nil {
+				mt.Fatalf("PatchProject: %v", err)
+			}
+
+			event := mt.GetStartedEvent()
+			for event != nil && event.CommandName != "update" {
+				event = mt.GetStartedEvent()
+			}
+			if event == nil {
+				mt.Fatal("no update command")
+			}
+			update := event.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u").Document()
+			_, err := update.LookupErr("$unset", "github")
+			if unset := err == nil; unset != tt.wantUnset {
+				mt.Fatalf("github unset = %v, want %v (update %s)", unset, tt.wantUnset, update)
+			}
+		})
+	}
+}
+
+func TestUploadProjectImageDeletesPreviousFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		fromGallery bool
+		wantDeleted bool
+	}{
+		{"uploaded image", false, true},
+		{"gallery cover", true, false},
+	}
+	for _, tt := range tests {
+		newMockDB(t, tt.name, func(mt *mtest.T) {
+			store := storage.NewLocalStorage(mt.TempDir(), "/uploads")
+			service := newTestProjectService(mt)
+			service.imageService = NewImageService(store)
+			ctx := context.Background()
+
+			previous, err := service.imageService.StoreImage(ctx, "projects", encodeTestPNG(mt.T, 64, 48), ProjectImageVariants)
+			if err != nil {
+				mt.Fatalf("StoreImage: %v", err)
+			}
+			project := models.Project{
+				ID:              primitive.NewObjectID(),
+				Title:           "App",
+				Image:           previous.URL,
+				ImageThumbnails: previous.Thumbnails,
+			}
+			if tt.fromGallery {
+				item := models.MediaItem{ID: primitive.NewObjectID(), Type: models.MediaTypeImage, URL: previous.URL, Thumbnails: previous.Thumbnails}
+				project.Gallery = []models.MediaItem{item}
+				project.CoverMediaID = &item.ID
+			}
+			mt.AddMockResponses(
+				findResponse("test.projects", mockDocuments(mt.T, project)...),
+				mtest.CreateSuccessResponse(),
+			)
+
+			updated, err := service.UploadProjectImage(ctx, project.ID.Hex(), encodeTestPNG(mt.T, 64, 48))
+			if err != nil {
+				mt.Fatalf("UploadProjectImage: %v", err)
+			}
+			if updated.Image == previous.URL || updated.CoverMediaID != nil {
+				mt.Fatalf("image = %q, cover = %v", updated.Image, updated.CoverMediaID)
+			}
+
+			for _, url := range []string{previous.URL, previous.Thumbnails["small"]} {
+				key, _ := store.KeyForURL(url)
+				_, err := store.Get(ctx, key)
+				if deleted := errors.Is(err, storage.ErrNotFound); deleted != tt.wantDeleted {
+					mt.Errorf("%s: deleted = %v, want %v", key, deleted, tt.wantDeleted)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteProjectDeletesImageFiles(t *testing.T) {
+	newMockDB(t, "delete project", func(mt *mtest.T) {
+		store := storage.NewLocalStorage(mt.TempDir(), "/uploads")
+		service := newTestProjectService(mt)
+		service.imageService = NewImageService(store)
+		ctx := context.Background()
+
+		var images []StoredImage
+		for i := 0; i < 3; i++ {
+			stored, err := service.imageService.StoreImage(ctx, "projects", encodeTestPNG(mt.T, 64, 48), ProjectImageVariants)
+			if err != nil {
+				mt.Fatalf("StoreImage: %v", err)
+			}
+			images = append(images, stored)
+		}
+		cover := models.MediaItem{ID: primitive.NewObjectID(), Type: models.MediaTypeImage, URL: images[1].URL, Thumbnails: images[1].Thumbnails}
+		project := models.Project{
+			ID:              primitive.NewObjectID(),
+			Title:           "App",
+			Image:           images[1].URL,
+			ImageThumbnails: images[1].Thumbnails,
+			CoverMediaID:    &cover.ID,
+			Gallery: []models.MediaItem{
+				{ID: primitive.NewObjectID(), Type: models.MediaTypeImage, URL: images[0].URL, Thumbnails: images[0].Thumbnails},
+				cover,
+				{ID: primitive.NewObjectID(), Type: models.MediaTypeVideo, URL: "https://youtu.be/dQw4w9WgXcQ"},
+			},
+		}
+		mt.AddMockResponses(
+			findResponse("test.projects", mockDocuments(mt.T, project)...),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+		)
+
+		if err := service.DeleteProject(ctx, project.ID.Hex()); err != nil {
+			mt.Fatalf("DeleteProject: %v", err)
+		}
+
+		for i, image := range images {
+			key, _ := store.KeyForURL(image.Thumbnails["small"])
+			_, err := store.Get(ctx, key)
+			// Третье изображение не относится к проекту и должно остаться
+			if deleted := errors.Is(err, storage.ErrNotFound); deleted != (i < 2) {
+				mt.Errorf("image %d: deleted = %v", i, deleted)
+			}
+		}
+	})
+}
+
+func TestPatchProjectRejectsEmptyStatus(t *testing.T) {
+	for _, patch := range []string{`{"status": null}`, `{"status": ""}`} {
+		newMockDB(t, patch, func(mt *mtest.T) {
+			service := newTestProjectService(mt)
+			publishAt := time.Now().Add(time.Hour)
+			project := models.Project{ID: primitive.NewObjectID(), Title: "App", Status: models.ProjectDraft, PublishAt: &publishAt}
+			mt.AddMockResponses(findResponse("test.projects", mockDocuments(mt.T, project)...))
+
+			_, err := service.PatchProject(context.Background(), project.ID.Hex(), []byte(patch), "")
+			if !errors.Is(err, ErrInvalidPatch) {
+				mt.Fatalf("err = %v, want ErrInvalidPatch", err)
+			}
+			for _, event := range mt.GetAllStartedEvents() {
+				if event.CommandName == "update" {
+					mt.Fatal("project was updated")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// DefaultResumeTemplate шаблон резюме, используемый по умолчанию
+const DefaultResumeTemplate = "classic"
+
+var (
+	// ErrResumeDisabled возвращается, если пользователь запретил скачивание резюме
+	ErrResumeDisabled = errors.New("resume is disabled by the user")
+	// ErrUnknownResumeTemplate возвращается для неизвестного шаблона резюме
+	ErrUnknownResumeTemplate = errors.New("unknown resume template")
+)
+
+// resumeTemplate описывает оформление резюме
+type resumeTemplate struct {
+	accent     [3]int
+	text       [3]int
+	muted      [3]int
+	headerBand bool // заголовок на цветной плашке во всю ширину
+	nameSize   float64
+}
+
+// resumeTemplates доступные шаблоны резюме
+var resumeTemplates = map[string]resumeTemplate{
+	"classic": {
+		accent:   [3]int{33, 37, 41},
+		text:     [3]int{33, 37, 41},
+		muted:    [3]int{108, 117, 125},
+		nameSize: 24,
+	},
+	"modern": {
+		accent:     [3]int{37, 99, 235},
+		text:       [3]int{31, 41, 55},
+		muted:      [3]int{107, 114, 128},
+		headerBand: true,
+		nameSize:   26,
+	},
+}
+
+// ResumeTemplates возвращает названия доступных шаблонов резюме
+func ResumeTemplates() []string {
+	return []string{"classic", "modern"}
+}
+
+// ResumeService формирует PDF-резюме по данным профиля
+type ResumeService struct {
+	userRepo    *repositories.UserRepository
+	projectRepo *repositories.ProjectRepository
+}
+
+// NewResumeService создает новый сервис резюме
+func NewResumeService(userRepo *repositories.UserRepository, projectRepo *repositories.ProjectRepository) *ResumeService {
+	return &ResumeService{
+		userRepo:    userRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+// GenerateResume формирует PDF-резюме пользователя.
+// Для всех, кроме владельца, учитываются настройки приватности профиля.
+func (s *ResumeService) GenerateResume(ctx context.Context, userID, viewerID, templateName string) ([]byte, error) {
+	if templateName == "" {
+		templateName = DefaultResumeTemplate
+	}
+	tpl, ok := resumeTemplates[templateName]
+	if !ok {
+		return nil, ErrUnknownResumeTemplate
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if viewerID != userID {
+		if user.Privacy.ResumeDisabled {
+			return nil, ErrResumeDisabled
+		}
+		user = user.PublicView()
+	}
+
+	projects, err := s.projectRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderResume(tpl, user, onlyPublished(projects))
+}
+
+// renderResume рисует резюме по шаблону
+func renderResume(tpl resumeTemplate, user models.User, projects []models.Project) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(user.Name+" - Resume", true)
+	pdf.SetAuthor(user.Name, true)
+	pdf.SetMargins(18, 18, 18)
+	pdf.SetAutoPageBreak(true, 18)
+
+	// Шрифты Go поддерживают латиницу и кириллицу и встроены в бинарник
+	pdf.AddUTF8FontFromBytes("go", "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes("go", "B", gobold.TTF)
+	pdf.AddUTF8FontFromBytes("go", "I", goitalic.TTF)
+
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("go", "I", 8)
+		setColor(pdf.SetTextColor, tpl.muted)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%s - page %d of {nb}", user.Name, pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	contentWidth := pageWidth - left - right
+
+	// Заголовок: имя, должность и контакты
+	if tpl.headerBand {
+		setColor(pdf.SetFillColor, tpl.accent)
+		pdf.Rect(0, 0, pageWidth, 42, "F")
+		pdf.SetTextColor(255, 255, 255)
+	} else {
+		setColor(pdf.SetTextColor, tpl.text)
+	}
+	pdf.SetFont("go", "B", tpl.nameSize)
+	pdf.CellFormat(contentWidth, 11, user.Name, "", 1, "L", false, 0, "")
+	if user.Title != "" {
+		pdf.SetFont("go", "", 13)
+		pdf.CellFormat(contentWidth, 7, user.Title, "", 1, "L", false, 0, "")
+	}
+
+	contacts := resumeContacts(user)
+	if len(contacts) > 0 {
+		pdf.SetFont("go", "", 9)
+		if !tpl.headerBand {
+			setColor(pdf.SetTextColor, tpl.muted)
+		}
+		pdf.MultiCell(contentWidth, 5, strings.Join(contacts, "  |  "), "", "L", false)
+	}
+
+	if tpl.headerBand && pdf.GetY() < 46 {
+		pdf.SetY(46)
+	} else {
+		pdf.Ln(4)
+	}
+	if !tpl.headerBand {
+		setColor(pdf.SetDrawColor, tpl.accent)
+		pdf.SetLineWidth(0.5)
+		pdf.Line(left, pdf.GetY(), pageWidth-right, pdf.GetY())
+		pdf.Ln(4)
+	}
+
+	section := func(title string) {
+		if pdf.GetY() > 250 {
+			pdf.AddPage()
+		}
+		pdf.Ln(2)
+		pdf.SetFont("go", "B", 13)
+		setColor(pdf.SetTextColor, tpl.accent)
+		pdf.CellFormat(contentWidth, 8, strings.ToUpper(title), "", 1, "L", false, 0, "")
+		setColor(pdf.SetDrawColor, tpl.accent)
+		pdf.SetLineWidth(0.2)
+		pdf.Line(left, pdf.GetY(), left+contentWidth, pdf.GetY())
+		pdf.Ln(2)
+		setColor(pdf.SetTextColor, tpl.text)
+	}
+
+	if strings.TrimSpace(user.Bio) != "" {
+		section("About")
+		pdf.SetFont("go", "", 10)
+		pdf.MultiCell(contentWidth, 5, user.Bio, "", "L", false)
+	}
+
+	if len(user.Skills) > 0 {
+		section("Skills")
+		pdf.SetFont("go", "", 10)
+		pdf.MultiCell(contentWidth, 5, strings.Join(user.Skills, ", "), "", "L", false)
+	}
+
+	if user.Rating > 0 {
+		section("Rating")
+		pdf.SetFont("go", "", 10)
+		pdf.CellFormat(contentWidth, 5, fmt.Sprintf("%.1f / 5 based on peer reviews", user.Rating), "", 1, "L", false, 0, "")
+	}
+
+	if len(projects) > 0 {
+		section("Projects")
+		for _, project := range projects {
+			// Не разрываем заголовок проекта и начало описания между страницами
+			if pdf.GetY() > 255 {
+				pdf.AddPage()
+			}
+			pdf.SetFont("go", "B", 11)
+			setColor(pdf.SetTextColor, tpl.text)
+			pdf.MultiCell(contentWidth, 6, project.Title, "", "L", false)
+
+			if len(project.Technologies) > 0 {
+				pdf.SetFont("go", "I", 9)
+				setColor(pdf.SetTextColor, tpl.muted)
+				pdf.MultiCell(contentWidth, 5, strings.Join(project.Technologies, " · "), "", "L", false)
+			}
+
+			if project.Description != "" {
+				pdf.SetFont("go", "", 10)
+				setColor(pdf.SetTextColor, tpl.text)
+				pdf.MultiCell(contentWidth, 5, project.Description, "", "L", false)
+			}
+
+			for _, caseSection := range project.CaseStudy {
+				pdf.Ln(1)
+				pdf.SetFont("go", "B", 10)
+				setColor(pdf.SetTextColor, tpl.text)
+				pdf.MultiCell(contentWidth, 5, caseStudyTitle(caseSection.Type), "", "L", false)
+				pdf.SetFont("go", "", 10)
+				if caseSection.Content != "" {
+					pdf.MultiCell(contentWidth, 5, caseSection.Content, "", "L", false)
+				}
+				for _, metric := range caseSection.Metrics {
+					pdf.MultiCell(contentWidth, 5, "• "+metric.Label+": "+metric.Value, "", "L", false)
+				}
+			}
+
+			links := make([]string, 0, 2)
+			if project.GitHubURL != "" {
+				links = append(links, project.GitHubURL)
+			}
+			if project.LiveURL != "" {
+				links = append(links, project.LiveURL)
+			}
+			if len(links) > 0 {
+				pdf.SetFont("go", "", 9)
+				setColor(pdf.SetTextColor, tpl.accent)
+				pdf.MultiCell(contentWidth, 5, strings.Join(links, "  |  "), "", "L", false)
+			}
+			pdf.Ln(3)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resumeContacts возвращает контактные данные и социальные ссылки для заголовка резюме
+func resumeContacts(user models.User) []string {
+	contacts := make([]string, 0, 5)
+	for _, value := range []string{user.Email, user.Social.Website, user.Social.GitHub, user.Social.LinkedIn, user.Social.Twitter} {
+		if strings.TrimSpace(value) != "" {
+			contacts = append(contacts, value)
+		}
+	}
+	return contacts
+}
+
+// setColor применяет RGB-цвет через одну из функций SetTextColor/SetFillColor/SetDrawColor
+func setColor(apply func(r, g, b int), rgb [3]int) {
+	apply(rgb[0], rgb[1], rgb[2])
+}
@@ -8,6 +8,17 @@ import (
 	"your-project/backend/repositories"
 )
 
+// reviewReadOnlyFields поля отзыва, которые нельзя изменить через PATCH
+var reviewReadOnlyFields = map[string]bool{
+	"id":             true,
+	"userId":         true,
+	"reviewerId":     true,
+	"reviewerName":   true,
+	"reviewerAvatar": true,
+	"createdAt":      true,
+	"updatedAt":      true,
+}
+
 // ReviewService представляет сервис для работы с отзывами
 type ReviewService struct {
 	reviewRepo *repositories.ReviewRepository
@@ -69,6 +80,34 @@ func (s *ReviewService) UpdateReview(ctx context.Context, id string, review mode
 	return nil
 }
 
+// PatchReview частично обновляет отзыв по JSON Merge Patch (RFC 7396)
+func (s *ReviewService) PatchReview(ctx context.Context, id string, patch []byte) (models.Review, error) {
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Review{}, err
+	}
+
+	var patched models.Review
+	update, err := buildMergePatchUpdate(review, patch, reviewReadOnlyFields, &patched)
+	if err != nil {
+		return models.Review{}, err
+	}
+	if update.IsEmpty() {
+		return review, nil
+	}
+
+	if err := s.reviewRepo.Patch(ctx, id, update.Set, update.Unset); err != nil {
+		return models.Review{}, err
+	}
+
+	// Обновить средний рейтинг пользователя, если изменилась оценка
+	if patched.Rating != review.Rating {
+		s.updateUserRating(ctx, review.UserID.Hex())
+	}
+
+	return s.reviewRepo.FindByID(ctx, id)
+}
+
 // DeleteReview удаляет отзыв
 func (s *ReviewService) DeleteReview(ctx context.Context, id string) error {
 	// Получить отзыв перед удалением для получения ID пользователя
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"log"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+	"your-project/backend/socialcard"
+	"your-project/backend/storage"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// SocialCardBrand название сайта на карточках
+	SocialCardBrand = "DevProfile"
+	// socialCardVersion входит в хэш карточки; увеличивается при изменении оформления,
+	// чтобы не отдавать карточки, нарисованные по-старому
+	socialCardVersion = 1
+	// socialCardPrefix каталог хранилища для сгенерированных карточек
+	socialCardPrefix = "cards"
+)
+
+// ErrSocialCardNotFound возвращается, если пользователя или опубликованного проекта нет
+var ErrSocialCardNotFound = errors.New("social card not found")
+
+// SocialCard описывает карточку для предпросмотра ссылки до ее рендеринга
+type SocialCard struct {
+	// Hash - хэш содержимого карточки, под ним карточка хранится и отдается как ETag
+	Hash      string
+	card      socialcard.Card
+	avatarKey string
+}
+
+// SocialCardService формирует PNG-карточки Open Graph для профилей и проектов
+// и кеширует их в файловом хранилище по хэшу содержимого
+type SocialCardService struct {
+	userRepo    *repositories.UserRepository
+	projectRepo *repositories.ProjectRepository
+	storage     storage.Storage
+}
+
+// NewSocialCardService создает новый сервис карточек
+func NewSocialCardService(userRepo *repositories.UserRepository, projectRepo *repositories.ProjectRepository, storage storage.Storage) *SocialCardService {
+	return &SocialCardService{
+		userRepo:    userRepo,
+		projectRepo: projectRepo,
+		storage:     storage,
+	}
+}
+
+// UserCard возвращает карточку профиля с учетом настроек приватности
+func (s *SocialCardService) UserCard(ctx context.Context, id string) (SocialCard, error) {
+	user, err := s.findUser(ctx, id)
+	if err != nil {
+		return SocialCard{}, err
+	}
+	user = user.PublicView()
+
+	card := socialcard.Card{
+		Kind:       socialcard.KindProfile,
+		Title:      user.Name,
+		Subtitle:   user.Title,
+		AvatarName: user.Name,
+		Chips:      user.Skills,
+		Brand:      SocialCardBrand,
+	}
+	if user.Rating > 0 {
+		card.Footer = fmt.Sprintf("Rating %.1f / 5", user.Rating)
+	}
+	return s.newSocialCard(card, s.avatarKey(user))
+}
+
+// ProjectCard возвращает карточку опубликованного проекта
+func (s *SocialCardService) ProjectCard(ctx context.Context, id string) (SocialCard, error) {
+	project, err := s.projectRepo.FindByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return SocialCard{}, ErrSocialCardNotFound
+	}
+	if err != nil {
+		return SocialCard{}, err
+	}
+	// Черновики и архивные проекты не показываются посторонним
+	if !project.IsPublished() {
+		return SocialCard{}, ErrSocialCardNotFound
+	}
+
+	card := socialcard.Card{
+		Kind:       socialcard.KindProject,
+		Title:      project.Title,
+		Subtitle:   "by " + project.UserName,
+		AvatarName: project.UserName,
+		Chips:      project.Technologies,
+		Brand:      SocialCardBrand,
+	}
+	switch {
+	case project.StarCount == 1:
+		card.Footer = "1 star"
+	case project.StarCount > 1:
+		card.Footer = fmt.Sprintf("%d stars", project.StarCount)
+	}
+
+	// Аватар берется из актуального профиля владельца
+	avatarKey := ""
+	if owner, err := s.userRepo.FindByID(ctx, project.UserID.Hex()); err == nil {
+		avatarKey = s.avatarKey(owner)
+	}
+	return s.newSocialCard(card, avatarKey)
+}
+
+// Image возвращает PNG карточки: из хранилища, если она уже нарисована, иначе рисует и сохраняет ее
+func (s *SocialCardService) Image(ctx context.Context, card SocialCard) ([]byte, error) {
+	key := socialCardPrefix + "/" + card.Hash + ".png"
+	data, err := s.storage.Get(ctx, key)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+
+	cacheable := true
+	if card.avatarKey != "" {
+		avatar, err := s.loadAvatar(ctx, card.avatarKey)
+		if err != nil {
+			// Рисуем инициалы, но не кешируем карточку, чтобы попробовать снова в следующий раз
+			log.Printf("Failed to load avatar %s for social card: %v", card.avatarKey, err)
+			cacheable = false
+		}
+		card.card.Avatar = avatar
+	}
+
+	data, err = socialcard.Render(card.card)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		if _, err := s.storage.Put(ctx, key, "image/png", data); err != nil {
+			log.Printf("Failed to store social card %s: %v", key, err)
+		}
+	}
+	return data, nil
+}
+
+// newSocialCard вычисляет хэш содержимого карточки
+func (s *SocialCardService) newSocialCard(card socialcard.Card, avatarKey string) (SocialCard, error) {
+	content, err := json.Marshal(struct {
+		Version    int
+		Kind       string
+		Title      string
+		Subtitle   string
+		AvatarName string
+		AvatarKey  string
+		Chips      []string
+		Footer     string
+		Brand      string
+	}{socialCardVersion, card.Kind, card.Title, card.Subtitle, card.AvatarName, avatarKey, card.Chips, card.Footer, card.Brand})
+	if err != nil {
+		return SocialCard{}, err
+	}
+
+	sum := sha256.Sum256(content)
+	return SocialCard{
+		Hash:      hex.EncodeToString(sum[:]),
+		card:      card,
+		avatarKey: avatarKey,
+	}, nil
+}
+
+// findUser загружает пользователя, превращая отсутствие и некорректный ID в ErrSocialCardNotFound
+func (s *SocialCardService) findUser(ctx context.Context, id string) (models.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return models.User{}, ErrSocialCardNotFound
+	}
+	return user, err
+}
+
+// avatarKey возвращает ключ аватара в хранилище. Используются только загруженные аватары:
+// произвольные внешние адреса сервер не запрашивает.
+func (s *SocialCardService) avatarKey(user models.User) string {
+	for _, url := range []string{user.AvatarThumbnails["large"], user.Avatar} {
+		if url == "" {
+			continue
+		}
+		if key, ok := s.storage.KeyForURL(url); ok {
+			return key
+		}
+	}
+	return ""
+}
+
+// loadAvatar читает и декодирует аватар из хранилища
+func (s *SocialCardService) loadAvatar(ctx context.Context, key string) (image.Image, error) {
+	data, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+	avatar, _, err := image.Decode(bytes.NewReader(data))
+	return avatar, err
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrAlreadyStarred возвращается при повторной отметке проекта
+	ErrAlreadyStarred = errors.New("project is already starred")
+	// ErrNotStarred возвращается при снятии отсутствующей отметки
+	ErrNotStarred = errors.New("project is not starred")
+)
+
+// StarService представляет сервис отметок проектов
+type StarService struct {
+	starRepo    *repositories.StarRepository
+	projectRepo *repositories.ProjectRepository
+	userRepo    *repositories.UserRepository
+}
+
+// NewStarService создает новый сервис отметок
+func NewStarService(starRepo *repositories.StarRepository, projectRepo *repositories.ProjectRepository, userRepo *repositories.UserRepository) *StarService {
+	return &StarService{
+		starRepo:    starRepo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// StarProject отмечает проект от имени пользователя и увеличивает счетчик отметок
+func (s *StarService) StarProject(ctx context.Context, projectID, userID string) (models.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return models.Project{}, err
+	}
+	if !project.IsVisibleTo(userID) {
+		return models.Project{}, mongo.ErrNoDocuments
+	}
+
+	// Получить информацию о пользователе для добавления в отметку
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	created, err := s.starRepo.Create(ctx, models.Star{
+		ProjectID:  project.ID,
+		UserID:     user.ID,
+		UserName:   user.Name,
+		UserAvatar: user.Avatar,
+	})
+	if err != nil {
+		return models.Project{}, err
+	}
+	if !created {
+		return models.Project{}, ErrAlreadyStarred
+	}
+
+	if err := s.projectRepo.IncrementStarCount(ctx, project.ID, 1); err != nil {
+		return models.Project{}, err
+	}
+
+	return s.projectRepo.FindByID(ctx, projectID)
+}
+
+// UnstarProject снимает отметку пользователя и уменьшает счетчик отметок
+func (s *StarService) UnstarProject(ctx context.Context, projectID, userID string) (models.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	deleted, err := s.starRepo.Delete(ctx, project.ID, userObjectID)
+	if err != nil {
+		return models.Project{}, err
+	}
+	if !deleted {
+		return models.Project{}, ErrNotStarred
+	}
+
+	if err := s.projectRepo.IncrementStarCount(ctx, project.ID, -1); err != nil {
+		return models.Project{}, err
+	}
+
+	return s.projectRepo.FindByID(ctx, projectID)
+}
+
+// GetStargazers возвращает отметки проекта (кто и когда отметил), начиная с новых;
+// отметки черновика или архивного проекта видят только его участники
+func (s *StarService) GetStargazers(ctx context.Context, projectID, viewerID string) ([]models.Star, error) {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if !project.IsVisibleTo(viewerID) {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	stars, err := s.starRepo.FindByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	if stars == nil {
+		stars = []models.Star{}
+	}
+	return stars, nil
+}
+
+// GetStarredProjects возвращает проекты, отмеченные пользователем, начиная с последних отмеченных
+func (s *StarService) GetStarredProjects(ctx context.Context, userID string) ([]models.Project, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stars, err := s.starRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stars) == 0 {
+		return []models.Project{}, nil
+	}
+
+	position := make(map[primitive.ObjectID]int, len(stars))
+	ids := make([]primitive.ObjectID, len(stars))
+	for i, star := range stars {
+		position[star.ProjectID] = i
+		ids[i] = star.ProjectID
+	}
+
+	// Удаленные и снятые с публикации проекты не попадут в результат
+	projects, err := s.projectRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	projects = onlyPublished(projects)
+	sort.Slice(projects, func(i, j int) bool {
+		return position[projects[i].ID] < position[projects[j].ID]
+	})
+
+	return projects, nil
+}
+
+// IsStarred проверяет, отметил ли пользователь проект
+func (s *StarService) IsStarred(ctx context.Context, projectID, userID string) (bool, error) {
+	projectObjectID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return false, err
+	}
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, err
+	}
+	return s.starRepo.Exists(ctx, projectObjectID, userObjectID)
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+)
+
+const (
+	// TechnologyRefreshInterval период пересчета технологий для автодополнения
+	TechnologyRefreshInterval = 30 * time.Minute
+	// DefaultTechnologyLimit количество подсказок по умолчанию
+	DefaultTechnologyLimit = 10
+	// MaxTechnologyLimit максимальное количество подсказок
+	MaxTechnologyLimit = 50
+)
+
+// technologyAliases сокращения и альтернативные названия технологий (в нормализованном виде),
+// которые учитываются вместе с основным названием
+var technologyAliases = map[string]string{
+	"golang":       "go",
+	"js":           "javascript",
+	"ts":           "typescript",
+	"nodejs":       "node.js",
+	"node":         "node.js",
+	"reactjs":      "react",
+	"react.js":     "react",
+	"vuejs":        "vue.js",
+	"vue":          "vue.js",
+	"postgres":     "postgresql",
+	"k8s":          "kubernetes",
+	"mongo":        "mongodb",
+	"py":           "python",
+	"c sharp":      "c#",
+	"csharp":       "c#",
+	"dotnet":       ".net",
+	"tailwind":     "tailwindcss",
+	"tailwind css": "tailwindcss",
+}
+
+// TechnologyService формирует подсказки технологий по проектам и навыкам пользователей
+type TechnologyService struct {
+	technologyRepo *repositories.TechnologyRepository
+	projectRepo    *repositories.ProjectRepository
+	userRepo       *repositories.UserRepository
+	now            func() time.Time
+}
+
+// NewTechnologyService создает новый сервис технологий
+func NewTechnologyService(technologyRepo *repositories.TechnologyRepository, projectRepo *repositories.ProjectRepository, userRepo *repositories.UserRepository) *TechnologyService {
+	return &TechnologyService{
+		technologyRepo: technologyRepo,
+		projectRepo:    projectRepo,
+		userRepo:       userRepo,
+		now:            time.Now,
+	}
+}
+
+// Suggest возвращает технологии, название или альтернативное написание которых начинается с prefix,
+// начиная с самых популярных; пустой prefix возвращает самые популярные технологии
+func (s *TechnologyService) Suggest(ctx context.Context, prefix string, limit int) ([]models.Technology, error) {
+	if limit <= 0 {
+		limit = DefaultTechnologyLimit
+	}
+	if limit > MaxTechnologyLimit {
+		limit = MaxTechnologyLimit
+	}
+	return s.technologyRepo.Search(ctx, normalizeTechnologyTerm(prefix), int64(limit))
+}
+
+// Refresh пересчитывает использование технологий в опубликованных проектах и навыках пользователей
+func (s *TechnologyService) Refresh(ctx context.Context) error {
+	projectCounts, err := s.projectRepo.CountTechnologies(ctx)
+	if err != nil {
+		return err
+	}
+	skillCounts, err := s.userRepo.CountSkills(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.technologyRepo.ReplaceAll(ctx, mergeTechnologyCounts(projectCounts, skillCounts), s.now())
+}
+
+// Run пересчитывает технологии сразу и затем с периодом interval, пока не отменен ctx
+func (s *TechnologyService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil {
+			log.Printf("Failed to refresh technologies: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// mergeTechnologyCounts объединяет написания одной технологии. Названием технологии становится
+// самое частое написание без учета альтернативных названий (например, "Go", а не "golang").
+func mergeTechnologyCounts(projectCounts, skillCounts []models.NameCount) []models.Technology {
+	type spelling struct {
+		name  string
+		count int
+		alias bool
+	}
+	technologies := make(map[string]*models.Technology)
+	spellings := make(map[string][]spelling)
+
+	add := func(counts []models.NameCount, isProject bool) {
+		for _, item := range counts {
+			name := strings.Join(strings.Fields(item.Name), " ")
+			term := normalizeTechnologyTerm(name)
+			if term == "" {
+				continue
+			}
+			key := term
+			if canonical, ok := technologyAliases[term]; ok {
+				key = canonical
+			}
+
+			technology, ok := technologies[key]
+			if !ok {
+				technology = &models.Technology{Key: key, Terms: []string{key}}
+				technologies[key] = technology
+			}
+			if !containsString(technology.Terms, term) {
+				technology.Terms = append(technology.Terms, term)
+			}
+			if isProject {
+				technology.ProjectCount += item.Count
+			} else {
+				technology.SkillCount += item.Count
+			}
+			technology.Count += item.Count
+
+			// Одно написание может встретиться и в проектах, и в навыках
+			found := false
+			for i := range spellings[key] {
+				if spellings[key][i].name == name {
+					spellings[key][i].count += item.Count
+					found = true
+				}
+			}
+			if !found {
+				spellings[key] = append(spellings[key], spelling{name: name, count: item.Count, alias: term != key})
+			}
+		}
+	}
+	add(projectCounts, true)
+	add(skillCounts, false)
+
+	result := make([]models.Technology, 0, len(technologies))
+	for key, technology := range technologies {
+		candidates := spellings[key]
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].alias != candidates[j].alias {
+				return !candidates[i].alias
+			}
+			if candidates[i].count != candidates[j].count {
+				return candidates[i].count > candidates[j].count
+			}
+			return candidates[i].name < candidates[j].name
+		})
+		technology.Name = candidates[0].name
+		sort.Strings(technology.Terms)
+		result = append(result, *technology)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// normalizeTechnologyTerm приводит название технологии к виду для сравнения и поиска
+func normalizeTechnologyTerm(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
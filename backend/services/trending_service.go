@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"your-project/backend/models"
+	"your-project/backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// TrendingInterval период пересчета рейтинга популярных проектов
+	TrendingInterval = 15 * time.Minute
+	// TrendingWindow период, за который учитываются отметки, просмотры и комментарии
+	TrendingWindow = 7 * 24 * time.Hour
+	// TrendingGravity скорость, с которой старые события теряют вес (как в Hacker News)
+	TrendingGravity = 1.8
+	// DefaultTrendingLimit количество проектов в ответе по умолчанию
+	DefaultTrendingLimit = 20
+	// MaxTrendingProjects количество проектов в общем рейтинге и в рейтинге по технологии
+	MaxTrendingProjects = 50
+)
+
+// Вес событий в оценке популярности
+const (
+	trendingStarWeight    = 3.0
+	trendingCommentWeight = 2.0
+	trendingViewWeight    = 1.0
+)
+
+// TrendingService рассчитывает рейтинг популярных проектов и хранит его до следующего пересчета
+type TrendingService struct {
+	projectRepo   *repositories.ProjectRepository
+	starRepo      *repositories.StarRepository
+	commentRepo   *repositories.CommentRepository
+	analyticsRepo *repositories.AnalyticsRepository
+	now           func() time.Time
+
+	mu sync.RWMutex
+	// generatedAt время последнего пересчета рейтинга
+	generatedAt time.Time
+	// overall общий рейтинг
+	overall []models.TrendingProject
+	// byTechnology рейтинги по технологиям; ключ - название технологии в нижнем регистре
+	byTechnology map[string][]models.TrendingProject
+	// technologies названия технологий, для которых есть рейтинг
+	technologies []string
+}
+
+// NewTrendingService создает новый сервис популярных проектов
+func NewTrendingService(projectRepo *repositories.ProjectRepository, starRepo *repositories.StarRepository, commentRepo *repositories.CommentRepository, analyticsRepo *repositories.AnalyticsRepository) *TrendingService {
+	return &TrendingService{
+		projectRepo:   projectRepo,
+		starRepo:      starRepo,
+		commentRepo:   commentRepo,
+		analyticsRepo: analyticsRepo,
+		now:           time.Now,
+	}
+}
+
+// GetTrending возвращает популярные проекты; если задана technology - только проекты с этой технологией.
+// До первого пересчета рейтинг рассчитывается при запросе.
+func (s *TrendingService) GetTrending(ctx context.Context, technology string, limit int) (models.TrendingList, error) {
+	s.mu.RLock()
+	ready := !s.generatedAt.IsZero()
+	s.mu.RUnlock()
+	if !ready {
+		if err := s.Refresh(ctx); err != nil {
+			return models.TrendingList{}, err
+		}
+	}
+
+	if limit <= 0 {
+		limit = DefaultTrendingLimit
+	}
+	if limit > MaxTrendingProjects {
+		limit = MaxTrendingProjects
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	projects := s.overall
+	if technology != "" {
+		projects = s.byTechnology[strings.ToLower(strings.TrimSpace(technology))]
+	}
+	if len(projects) > limit {
+		projects = projects[:limit]
+	}
+
+	list := models.TrendingList{
+		GeneratedAt:  s.generatedAt,
+		Technology:   technology,
+		Projects:     append([]models.TrendingProject{}, projects...),
+		Technologies: s.technologies,
+	}
+	return list, nil
+}
+
+// Refresh пересчитывает рейтинг популярных проектов
+func (s *TrendingService) Refresh(ctx context.Context) error {
+	now := s.now()
+	scores, err := s.scoreProjects(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	projects, err := s.projectRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	ranked := make([]models.TrendingProject, 0, len(projects))
+	for _, project := range projects {
+		if project.IsPublished() {
+			ranked = append(ranked, models.TrendingProject{Project: project, TrendingScore: scores[project.ID]})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].TrendingScore != ranked[j].TrendingScore {
+			return ranked[i].TrendingScore > ranked[j].TrendingScore
+		}
+		return ranked[i].CreatedAt.After(ranked[j].CreatedAt)
+	})
+
+	// Рейтинги по технологиям; название технологии берется из самого популярного проекта
+	byTechnology := make(map[string][]models.TrendingProject)
+	var technologies []string
+	for _, project := range ranked {
+		seen := make(map[string]bool, len(project.Technologies))
+		for _, technology := range project.Technologies {
+			key := strings.ToLower(strings.TrimSpace(technology))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			if _, ok := byTechnology[key]; !ok {
+				technologies = append(technologies, strings.TrimSpace(technology))
+			}
+			if len(byTechnology[key]) < MaxTrendingProjects {
+				byTechnology[key] = append(byTechnology[key], project)
+			}
+		}
+	}
+	if len(ranked) > MaxTrendingProjects {
+		ranked = ranked[:MaxTrendingProjects]
+	}
+	sort.Slice(technologies, func(i, j int) bool {
+		return strings.ToLower(technologies[i]) < strings.ToLower(technologies[j])
+	})
+	if technologies == nil {
+		technologies = []string{}
+	}
+
+	s.mu.Lock()
+	s.generatedAt = now
+	s.overall = ranked
+	s.byTechnology = byTechnology
+	s.technologies = technologies
+	s.mu.Unlock()
+	return nil
+}
+
+// Run периодически пересчитывает рейтинг, пока не будет отменен ctx
+func (s *TrendingService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil {
+			log.Printf("Failed to refresh trending projects: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scoreProjects рассчитывает оценку проектов с активностью за TrendingWindow: каждое событие дня
+// дает вес своего типа, деленный на (возраст в часах + 2) ^ TrendingGravity
+func (s *TrendingService) scoreProjects(ctx context.Context, now time.Time) (map[primitive.ObjectID]float64, error) {
+	since := now.Add(-TrendingWindow)
+
+	stars, err := s.starRepo.CountDailySince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.commentRepo.CountDailySince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	views, err := s.analyticsRepo.FindDailyViewsSince(ctx, models.ViewTargetProject, since.UTC().Format(analyticsDayLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[primitive.ObjectID]float64)
+	add := func(activity []models.DailyActivity, weight float64) {
+		for _, day := range activity {
+			scores[day.ProjectID] += weight * float64(day.Count) / math.Pow(activityAgeHours(day.Day, now)+2, TrendingGravity)
+		}
+	}
+	add(stars, trendingStarWeight)
+	add(comments, trendingCommentWeight)
+	add(views, trendingViewWeight)
+
+	return scores, nil
+}
+
+// activityAgeHours возвращает возраст событий дня в часах, считая от середины дня
+func activityAgeHours(day string, now time.Time) float64 {
+	start, err := time.Parse(analyticsDayLayout, day)
+	if err != nil {
+		return TrendingWindow.Hours()
+	}
+	age := now.Sub(start.Add(12 * time.Hour)).Hours()
+	if age < 0 {
+		return 0
+	}
+	return age
+}
@@ -4,7 +4,8 @@ package services
 import (
 	"context"
 	"errors"
-	"time"
+	"log"
+	"strings"
 
 	"your-project/backend/models"
 	"your-project/backend/repositories"
@@ -12,14 +13,34 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrInvalidCredentials возвращается при неверном email или пароле
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// userReadOnlyFields поля пользователя, которые нельзя изменить через PATCH
+var userReadOnlyFields = map[string]bool{
+	"id":               true,
+	"email":            true, // меняется только через подтверждение нового адреса
+	"avatarThumbnails": true,
+	"bioHtml":          true, // формируется из bio
+	"rating":           true,
+	"pinnedProjects":   true, // закрепление и порядок проектов меняются через отдельные эндпоинты
+	"projectOrder":     true,
+	"createdAt":        true,
+	"updatedAt":        true,
+}
+
 // UserService представляет сервис для работы с пользователями
 type UserService struct {
-	userRepo *repositories.UserRepository
+	userRepo     *repositories.UserRepository
+	imageService *ImageService
 }
 
 // NewUserService создает новый сервис пользователей
-func NewUserService(userRepo *repositories.UserRepository) *UserService {
-	return &UserService{userRepo}
+func NewUserService(userRepo *repositories.UserRepository, imageService *ImageService) *UserService {
+	return &UserService{
+		userRepo:     userRepo,
+		imageService: imageService,
+	}
 }
 
 // GetAllUsers возвращает всех пользователей
@@ -36,7 +57,7 @@ func (s *UserService) GetUserByID(ctx context.Context, id string) (models.User,
 func (s *UserService) CreateUser(ctx context.Context, user models.User) (models.User, error) {
 	// Проверить, существует ли пользователь с таким email
 	existingUser, err := s.userRepo.FindByEmail(ctx, user.Email)
-	if err == nil && existingUser.ID != "" {
+	if err == nil && !existingUser.ID.IsZero() {
 		return models.User{}, errors.New("user with this email already exists")
 	}
 
@@ -50,7 +71,16 @@ func (s *UserService) CreateUser(ctx context.Context, user models.User) (models.
 	// Установить начальный рейтинг
 	user.Rating = 0
 
-	return s.userRepo.Create(ctx, user)
+	if err := renderUserBio(&user); err != nil {
+		return models.User{}, err
+	}
+
+	created, err := s.userRepo.Create(ctx, user)
+	if errors.Is(err, repositories.ErrEmailExists) {
+		// Пользователь с таким email зарегистрировался параллельно
+		return models.User{}, errors.New("user with this email already exists")
+	}
+	return created, err
 }
 
 // UpdateUser обновляет пользователя
@@ -60,6 +90,21 @@ func (s *UserService) UpdateUser(ctx context.Context, id string, user models.Use
 		return err
 	}
 
+	// Email меняется только через подтверждение нового адреса
+	if user.Email != "" && !strings.EqualFold(user.Email, existingUser.Email) {
+		return ErrEmailChangeRequiresConfirmation
+	}
+	user.Email = existingUser.Email
+
+	// Закрепленные проекты и их порядок меняются через отдельные эндпоинты;
+	// пустые значения не перезаписывают сохраненные благодаря omitempty
+	user.PinnedProjects = nil
+	user.ProjectOrder = nil
+
+	if err := renderUserBio(&user); err != nil {
+		return err
+	}
+
 	// Если пароль не был изменен, сохраняем старый хешированный пароль
 	if user.Password == "" {
 		user.Password = existingUser.Password
@@ -75,6 +120,81 @@ func (s *UserService) UpdateUser(ctx context.Context, id string, user models.Use
 	return s.userRepo.Update(ctx, id, user)
 }
 
+// PatchUser частично обновляет пользователя по JSON Merge Patch (RFC 7396)
+func (s *UserService) PatchUser(ctx context.Context, id string, patch []byte) (models.User, error) {
+	existingUser, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	var patched models.User
+	update, err := buildMergePatchUpdate(existingUser, patch, userReadOnlyFields, &patched)
+	if err != nil {
+		return models.User{}, err
+	}
+	if err := renderUserBio(&patched); err != nil {
+		return models.User{}, err
+	}
+	renderPatchedMarkdown(&update, "bio", "bio_html", patched.BioHTML)
+	if update.IsEmpty() {
+		return existingUser, nil
+	}
+
+	if err := s.userRepo.Patch(ctx, id, update.Set, update.Unset); err != nil {
+		return models.User{}, err
+	}
+
+	return s.userRepo.FindByID(ctx, id)
+}
+
+// RenderMissingBios сохраняет HTML биографий, заполненных до появления Markdown,
+// и возвращает количество обновленных пользователей
+func (s *UserService) RenderMissingBios(ctx context.Context) (int, error) {
+	users, err := s.userRepo.FindWithoutBioHTML(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, user := range users {
+		if err := renderUserBio(&user); err != nil {
+			return i, err
+		}
+		if err := s.userRepo.SetBioHTML(ctx, user.ID, user.BioHTML); err != nil {
+			return i, err
+		}
+	}
+	return len(users), nil
+}
+
+// UploadAvatar обрабатывает загруженное изображение и устанавливает его аватаром пользователя
+func (s *UserService) UploadAvatar(ctx context.Context, id string, data []byte) (models.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	stored, err := s.imageService.StoreImage(ctx, "avatars/"+id, data, AvatarVariants)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if err := s.userRepo.UpdateAvatar(ctx, id, stored.URL, stored.Thumbnails); err != nil {
+		return models.User{}, err
+	}
+
+	// Новый аватар уже сохранен, поэтому ошибка удаления прежних файлов только записывается в лог
+	if user.Avatar != "" {
+		previous := StoredImage{URL: user.Avatar, Thumbnails: user.AvatarThumbnails}
+		if err := s.imageService.DeleteImage(ctx, previous); err != nil {
+			log.Printf("Failed to delete previous avatar of user %s: %v", id, err)
+		}
+	}
+
+	user.Avatar = stored.URL
+	user.AvatarThumbnails = stored.Thumbnails
+	return user, nil
+}
+
 // DeleteUser удаляет пользователя
 func (s *UserService) DeleteUser(ctx context.Context, id string) error {
 	return s.userRepo.Delete(ctx, id)
@@ -94,13 +214,13 @@ func (s *UserService) SearchUsersBySkills(ctx context.Context, skill string) ([]
 func (s *UserService) AuthenticateUser(ctx context.Context, email, password string) (models.User, error) {
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
-		return models.User{}, errors.New("invalid credentials")
+		return models.User{}, ErrInvalidCredentials
 	}
 
 	// Проверить пароль
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return models.User{}, errors.New("invalid credentials")
+		return models.User{}, ErrInvalidCredentials
 	}
 
 	return user, nil
@@ -6,6 +6,10 @@ import (
 	"log"
 	"time"
 
+	"your-project/backend/models"
+	"your-project/backend/services"
+
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -15,15 +19,168 @@ func SetupDatabase(client *mongo.Client, dbName string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Example: Create indexes if needed
-	// userCollection := client.Database(dbName).Collection("users")
-	// _, err := userCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
-	//     Keys: bson.D{{"email", 1}},
-	//     Options: options.Index().SetUnique(true),
-	// })
-	// if err != nil {
-	//     return err
-	// }
+	db := client.Database(dbName)
+
+	indexes := map[string][]mongo.IndexModel{
+		// Email identifies the account at login and is checked for availability before changes
+		"users": {{
+			Keys:    bson.D{{Key: "email", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}},
+		"view_visits": {
+			// One visit per visitor per day, so repeated views are not counted
+			{
+				Keys: bson.D{
+					{Key: "target_type", Value: 1},
+					{Key: "target_id", Value: 1},
+					{Key: "day", Value: 1},
+					{Key: "visitor", Value: 1},
+				},
+				Options: options.Index().SetUnique(true),
+			},
+			// Visits dedupe views and count unique visitors; expire them once they fall out of the longest stats period
+			{
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(int32(services.VisitRetention / time.Second)),
+			},
+		},
+		"view_daily": {{
+			Keys: bson.D{
+				{Key: "target_type", Value: 1},
+				{Key: "target_id", Value: 1},
+				{Key: "day", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		}},
+		"view_referrers": {{
+			Keys: bson.D{
+				{Key: "target_type", Value: 1},
+				{Key: "target_id", Value: 1},
+				{Key: "day", Value: 1},
+				{Key: "referrer", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		}},
+		// One star per user per project
+		"stars": {
+			{
+				Keys: bson.D{
+					{Key: "project_id", Value: 1},
+					{Key: "user_id", Value: 1},
+				},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{
+					{Key: "user_id", Value: 1},
+					{Key: "created_at", Value: -1},
+				},
+			},
+			// Counting recent stars for trending projects
+			{
+				Keys: bson.D{{Key: "created_at", Value: 1}},
+			},
+		},
+		// Category slugs are referenced by projects
+		"categories": {{
+			Keys:    bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}},
+		// Revision numbers are sequential within a project
+		"project_revisions": {{
+			Keys: bson.D{
+				{Key: "project_id", Value: 1},
+				{Key: "number", Value: -1},
+			},
+			Options: options.Index().SetUnique(true),
+		}},
+		// One link state per user, project and field
+		"link_checks": {{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+				{Key: "project_id", Value: 1},
+				{Key: "field", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		}},
+		"comments": {
+			// Paging through a project's comments and replies
+			{
+				Keys: bson.D{
+					{Key: "project_id", Value: 1},
+					{Key: "parent_id", Value: 1},
+					{Key: "_id", Value: 1},
+				},
+			},
+			// Counting recent comments for trending projects
+			{
+				Keys: bson.D{{Key: "created_at", Value: 1}},
+			},
+		},
+		"collections": {
+			// Listing a user's collections, newest first
+			{
+				Keys: bson.D{
+					{Key: "user_id", Value: 1},
+					{Key: "created_at", Value: -1},
+				},
+			},
+			// Removing a deleted project from collections
+			{
+				Keys: bson.D{{Key: "project_ids", Value: 1}},
+			},
+		},
+		"technologies": {
+			// Autocomplete by prefix of any spelling
+			{
+				Keys: bson.D{{Key: "terms", Value: 1}},
+			},
+			// Most used technologies first
+			{
+				Keys: bson.D{{Key: "count", Value: -1}},
+			},
+		},
+		"projects": {
+			// Sorting projects by popularity
+			{
+				Keys: bson.D{
+					{Key: "star_count", Value: -1},
+					{Key: "created_at", Value: -1},
+				},
+			},
+			// Finding drafts due for scheduled publishing
+			{
+				Keys: bson.D{
+					{Key: "status", Value: 1},
+					{Key: "publish_at", Value: 1},
+				},
+			},
+		},
+	}
+
+	for collection, collectionIndexes := range indexes {
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, collectionIndexes); err != nil {
+			return err
+		}
+	}
+
+	// Seed the default project categories on first start; afterwards administrators manage them
+	categories := db.Collection("categories")
+	count, err := categories.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		defaults := make([]interface{}, 0, len(models.DefaultCategories))
+		for _, category := range models.DefaultCategories {
+			category.CreatedAt = time.Now()
+			category.UpdatedAt = time.Now()
+			defaults = append(defaults, category)
+		}
+		if _, err := categories.InsertMany(ctx, defaults); err != nil {
+			return err
+		}
+	}
 
 	log.Println("Database setup completed")
 	return nil
@@ -0,0 +1,305 @@
+package socialcard
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"sort"
+	"strings"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// Размер карточки, рекомендуемый для og:image
+const (
+	Width  = 1200
+	Height = 630
+)
+
+// Виды карточек
+const (
+	KindProfile = "profile"
+	KindProject = "project"
+)
+
+// Card описывает содержимое карточки для предпросмотра ссылки
+type Card struct {
+	Kind     string
+	Title    string      // Имя пользователя или название проекта
+	Subtitle string      // Должность пользователя или автор проекта
+	Avatar   image.Image // Аватар; если nil, рисуются инициалы из AvatarName
+	// AvatarName имя, из которого берутся инициалы при отсутствии аватара
+	AvatarName string
+	Chips      []string // Навыки или технологии
+	Footer     string   // Рейтинг или количество отметок
+	Brand      string   // Название сайта в правом нижнем углу
+}
+
+// Оформление карточки
+var (
+	backgroundTop    = color.RGBA{15, 23, 42, 255}
+	backgroundBottom = color.RGBA{30, 41, 59, 255}
+	accentColor      = color.RGBA{59, 130, 246, 255}
+	textColor        = color.RGBA{248, 250, 252, 255}
+	mutedColor       = color.RGBA{148, 163, 184, 255}
+	chipColor        = color.RGBA{51, 65, 85, 255}
+)
+
+const (
+	margin       = 80
+	maxChips     = 6
+	chipHeight   = 52
+	chipPaddingX = 22
+	chipGap      = 14
+
+	// maxTextRunes ограничивает длину измеряемого текста. Даже самым мелким шрифтом на карточку помещается
+	// меньше, а названия и имена не ограничены по длине, и измерение всего текста заняло бы минуты.
+	maxTextRunes = 400
+)
+
+// Render рисует карточку и возвращает ее в формате PNG
+func Render(card Card) ([]byte, error) {
+	faces, err := newFaceSet()
+	if err != nil {
+		return nil, err
+	}
+	defer faces.Close()
+
+	dst := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	drawBackground(dst)
+
+	if card.Kind == KindProject {
+		renderProject(dst, faces, card)
+	} else {
+		renderProfile(dst, faces, card)
+	}
+
+	if card.Footer != "" {
+		drawText(dst, faces.footer, mutedColor, margin, Height-60, truncate(faces.footer, card.Footer, Width/2-margin))
+	}
+	if card.Brand != "" {
+		brand := truncate(faces.footer, card.Brand, Width/2-margin)
+		x := Width - margin - font.MeasureString(faces.footer, brand).Ceil()
+		drawText(dst, faces.footer, accentColor, x, Height-60, brand)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderProfile располагает крупный аватар слева, имя и должность справа, навыки ниже
+func renderProfile(dst *image.RGBA, faces *faceSet, card Card) {
+	const avatarSize = 220
+	drawAvatar(dst, faces, card, margin, 90, avatarSize)
+
+	textX := margin + avatarSize + 50
+	textWidth := Width - margin - textX
+	y := 170
+	for _, line := range wrap(faces.title, card.Title, textWidth, 2) {
+		drawText(dst, faces.title, textColor, textX, y, line)
+		y += 76
+	}
+	if card.Subtitle != "" {
+		drawText(dst, faces.subtitle, mutedColor, textX, y+4, truncate(faces.subtitle, card.Subtitle, textWidth))
+	}
+
+	drawChips(dst, faces.chip, card.Chips, margin, 390, Width-2*margin)
+}
+
+// renderProject располагает название проекта сверху, автора с небольшим аватаром и технологии ниже
+func renderProject(dst *image.RGBA, faces *faceSet, card Card) {
+	const avatarSize = 64
+	y := 160
+	for _, line := range wrap(faces.title, card.Title, Width-2*margin, 2) {
+		drawText(dst, faces.title, textColor, margin, y, line)
+		y += 80
+	}
+
+	if card.Subtitle != "" {
+		top := y - 20
+		drawAvatar(dst, faces, card, margin, top, avatarSize)
+		textX := margin + avatarSize + 20
+		drawText(dst, faces.subtitle, mutedColor, textX, top+46, truncate(faces.subtitle, card.Subtitle, Width-margin-textX))
+		y = top + avatarSize + 40
+	}
+
+	drawChips(dst, faces.chip, card.Chips, margin, y, Width-2*margin)
+}
+
+// drawBackground заливает карточку вертикальным градиентом и рисует полосу акцентного цвета слева
+func drawBackground(dst *image.RGBA) {
+	for y := 0; y < Height; y++ {
+		t := float64(y) / float64(Height-1)
+		row := color.RGBA{
+			R: mix(backgroundTop.R, backgroundBottom.R, t),
+			G: mix(backgroundTop.G, backgroundBottom.G, t),
+			B: mix(backgroundTop.B, backgroundBottom.B, t),
+			A: 255,
+		}
+		draw.Draw(dst, image.Rect(0, y, Width, y+1), image.NewUniform(row), image.Point{}, draw.Src)
+	}
+	draw.Draw(dst, image.Rect(0, 0, 16, Height), image.NewUniform(accentColor), image.Point{}, draw.Src)
+}
+
+// drawAvatar рисует круглый аватар или, если его нет, круг с инициалами
+func drawAvatar(dst *image.RGBA, faces *faceSet, card Card, x, y, size int) {
+	rect := image.Rect(x, y, x+size, y+size)
+	mask := &roundedRect{rect: rect, radius: float64(size) / 2}
+
+	if card.Avatar != nil {
+		draw.DrawMask(dst, rect, squareThumbnail(card.Avatar, size), image.Point{}, mask, rect.Min, draw.Over)
+		return
+	}
+
+	draw.DrawMask(dst, rect, image.NewUniform(accentColor), image.Point{}, mask, rect.Min, draw.Over)
+	letters := initials(card.AvatarName)
+	if letters == "" {
+		return
+	}
+	face := faces.initialsLarge
+	if size < 100 {
+		face = faces.initialsSmall
+	}
+	metrics := face.Metrics()
+	textX := x + (size-font.MeasureString(face, letters).Ceil())/2
+	textY := y + (size+metrics.Ascent.Ceil()-metrics.Descent.Ceil())/2
+	drawText(dst, face, textColor, textX, textY, letters)
+}
+
+// squareThumbnail вырезает центральный квадрат изображения и масштабирует его до size
+func squareThumbnail(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, image.Rect(x0, y0, x0+side, y0+side), draw.Src, nil)
+	return dst
+}
+
+// drawChips рисует строку скругленных меток; метки, не поместившиеся в ширину, пропускаются
+func drawChips(dst *image.RGBA, face font.Face, chips []string, x, y, width int) {
+	if len(chips) > maxChips {
+		chips = chips[:maxChips]
+	}
+
+	metrics := face.Metrics()
+	right := x + width
+	for _, chip := range chips {
+		label := truncate(face, chip, width/2)
+		chipWidth := font.MeasureString(face, label).Ceil() + 2*chipPaddingX
+		if x+chipWidth > right {
+			break
+		}
+
+		rect := image.Rect(x, y, x+chipWidth, y+chipHeight)
+		draw.DrawMask(dst, rect, image.NewUniform(chipColor), image.Point{}, &roundedRect{rect: rect, radius: chipHeight / 2}, rect.Min, draw.Over)
+		baseline := y + (chipHeight+metrics.Ascent.Ceil()-metrics.Descent.Ceil())/2
+		drawText(dst, face, textColor, x+chipPaddingX, baseline, label)
+
+		x += chipWidth + chipGap
+	}
+}
+
+// drawText выводит строку текста; y - положение базовой линии
+func drawText(dst *image.RGBA, face font.Face, c color.Color, x, y int, text string) {
+	drawer := font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
+
+// wrap разбивает текст на строки не шире width; последняя строка обрезается с многоточием
+func wrap(face font.Face, text string, width, maxLines int) []string {
+	if limited, cut := limitRunes(text, maxTextRunes); cut {
+		text = limited + "…"
+	}
+	words := strings.Fields(text)
+	var lines []string
+	current := ""
+	for i, word := range words {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if current == "" || font.MeasureString(face, candidate).Ceil() <= width {
+			current = candidate
+			continue
+		}
+
+		if len(lines) == maxLines-1 {
+			// Оставшиеся слова не помещаются: обрезаем последнюю строку
+			current = strings.Join(append([]string{current}, words[i:]...), " ")
+			break
+		}
+		lines = append(lines, truncate(face, current, width))
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, truncate(face, current, width))
+	}
+	return lines
+}
+
+// truncate обрезает текст до ширины width, добавляя многоточие
+func truncate(face font.Face, text string, width int) string {
+	limited, cut := limitRunes(text, maxTextRunes)
+	if !cut && font.MeasureString(face, text).Ceil() <= width {
+		return text
+	}
+
+	// Двоичный поиск самого длинного начала текста, которое помещается вместе с многоточием
+	runes := []rune(limited)
+	ellipsized := func(n int) string {
+		return strings.TrimSpace(string(runes[:n])) + "…"
+	}
+	n := sort.Search(len(runes)+1, func(n int) bool {
+		return font.MeasureString(face, ellipsized(n)).Ceil() > width
+	}) - 1
+	if n < 0 {
+		return ""
+	}
+	return ellipsized(n)
+}
+
+// limitRunes возвращает не больше max первых символов текста и признак того, что текст был обрезан
+func limitRunes(text string, max int) (string, bool) {
+	count := 0
+	for i := range text {
+		if count == max {
+			return text[:i], true
+		}
+		count++
+	}
+	return text, false
+}
+
+// initials возвращает до двух первых букв слов имени в верхнем регистре
+func initials(name string) string {
+	var letters []rune
+	for _, word := range strings.Fields(name) {
+		letters = append(letters, []rune(word)[0])
+		if len(letters) == 2 {
+			break
+		}
+	}
+	return strings.ToUpper(string(letters))
+}
+
+// mix линейно интерполирует компонент цвета
+func mix(from, to uint8, t float64) uint8 {
+	return uint8(float64(from) + (float64(to)-float64(from))*t + 0.5)
+}
@@ -0,0 +1,92 @@
+package socialcard
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/image/font"
+)
+
+func TestRenderLongText(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+	}{
+		{"one long word", strings.Repeat("W", 20000)},
+		{"many short words", strings.Repeat("go ", 60000)},
+		{"multibyte", strings.Repeat("проект ", 20000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, kind := range []string{KindProject, KindProfile} {
+				start := time.Now()
+				data, err := Render(Card{
+					Kind:       kind,
+					Title:      tt.title,
+					Subtitle:   tt.title,
+					AvatarName: tt.title,
+					Chips:      []string{tt.title, tt.title},
+					Footer:     tt.title,
+					Brand:      tt.title,
+				})
+				if err != nil {
+					t.Fatalf("%s: Render: %v", kind, err)
+				}
+				// Без ограничения такие названия рендерились от десятков секунд до нескольких минут
+				if elapsed := time.Since(start); elapsed > 5*time.Second {
+					t.Fatalf("%s: Render took %v", kind, elapsed)
+				}
+				if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+					t.Fatalf("%s: invalid PNG: %v", kind, err)
+				}
+			}
+		})
+	}
+}
+
+func TestTruncateAndWrap(t *testing.T) {
+	faces, err := newFaceSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer faces.Close()
+
+	const width = 400
+	if got := truncate(faces.subtitle, "Short", width); got != "Short" {
+		t.Errorf("truncate(short) = %q", got)
+	}
+
+	for _, text := range []string{strings.Repeat("x", 1000), strings.Repeat("ab ", 5000)} {
+		got := truncate(faces.subtitle, text, width)
+		if !strings.HasSuffix(got, "…") {
+			t.Errorf("truncate = %q, want an ellipsis", got)
+		}
+		if w := font.MeasureString(faces.subtitle, got).Ceil(); w > width {
+			t.Errorf("truncate width = %d, want at most %d", w, width)
+		}
+		// Обрезается по ширине, а не до нескольких символов
+		if w := font.MeasureString(faces.subtitle, got).Ceil(); w < width*3/4 {
+			t.Errorf("truncate = %q is only %d wide", got, w)
+		}
+
+		lines := wrap(faces.title, text, width, 2)
+		if len(lines) > 2 {
+			t.Fatalf("wrap returned %d lines", len(lines))
+		}
+		for _, line := range lines {
+			if w := font.MeasureString(faces.title, line).Ceil(); w > width {
+				t.Errorf("wrap line %q is %d wide", line, w)
+			}
+		}
+		if !strings.HasSuffix(lines[len(lines)-1], "…") {
+			t.Errorf("wrap last line = %q, want an ellipsis", lines[len(lines)-1])
+		}
+	}
+
+	if got := truncate(faces.subtitle, "Anything", 1); got != "" {
+		t.Errorf("truncate to 1px = %q, want empty", got)
+	}
+}
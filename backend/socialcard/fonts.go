@@ -0,0 +1,83 @@
+package socialcard
+
+import (
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// Шрифты Go встроены в бинарный файл, поэтому рендеринг не зависит от шрифтов системы
+var (
+	fontsOnce   sync.Once
+	regularFont *opentype.Font
+	boldFont    *opentype.Font
+	fontsErr    error
+)
+
+// loadFonts разбирает встроенные шрифты один раз
+func loadFonts() error {
+	fontsOnce.Do(func() {
+		regularFont, fontsErr = opentype.Parse(goregular.TTF)
+		if fontsErr != nil {
+			return
+		}
+		boldFont, fontsErr = opentype.Parse(gobold.TTF)
+	})
+	return fontsErr
+}
+
+// faceSet начертания, используемые на карточке. Начертания хранят внутренние буферы,
+// поэтому каждый рендеринг создает свой набор.
+type faceSet struct {
+	title         font.Face
+	subtitle      font.Face
+	chip          font.Face
+	footer        font.Face
+	initialsLarge font.Face
+	initialsSmall font.Face
+}
+
+// newFaceSet создает начертания нужных размеров
+func newFaceSet() (*faceSet, error) {
+	if err := loadFonts(); err != nil {
+		return nil, err
+	}
+
+	faces := &faceSet{}
+	for _, spec := range []struct {
+		target *font.Face
+		font   *opentype.Font
+		size   float64
+	}{
+		{&faces.title, boldFont, 64},
+		{&faces.subtitle, regularFont, 36},
+		{&faces.chip, regularFont, 26},
+		{&faces.footer, regularFont, 28},
+		{&faces.initialsLarge, boldFont, 88},
+		{&faces.initialsSmall, boldFont, 26},
+	} {
+		face, err := opentype.NewFace(spec.font, &opentype.FaceOptions{
+			Size:    spec.size,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			faces.Close()
+			return nil, err
+		}
+		*spec.target = face
+	}
+	return faces, nil
+}
+
+// Close освобождает начертания
+func (f *faceSet) Close() {
+	for _, face := range []font.Face{f.title, f.subtitle, f.chip, f.footer, f.initialsLarge, f.initialsSmall} {
+		if face != nil {
+			face.Close()
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package socialcard
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// roundedRect маска прямоугольника со скругленными углами и сглаженными краями.
+// Круг - частный случай с радиусом, равным половине стороны квадрата.
+type roundedRect struct {
+	rect   image.Rectangle
+	radius float64
+}
+
+// ColorModel реализует image.Image
+func (r *roundedRect) ColorModel() color.Model {
+	return color.AlphaModel
+}
+
+// Bounds реализует image.Image
+func (r *roundedRect) Bounds() image.Rectangle {
+	return r.rect
+}
+
+// At возвращает непрозрачность маски в точке
+func (r *roundedRect) At(x, y int) color.Color {
+	if !(image.Point{X: x, Y: y}).In(r.rect) {
+		return color.Alpha{}
+	}
+
+	// Центр пикселя относительно ближайшего центра скругления
+	px := float64(x) + 0.5
+	py := float64(y) + 0.5
+	minX := float64(r.rect.Min.X) + r.radius
+	maxX := float64(r.rect.Max.X) - r.radius
+	minY := float64(r.rect.Min.Y) + r.radius
+	maxY := float64(r.rect.Max.Y) - r.radius
+	dx := math.Max(math.Max(minX-px, px-maxX), 0)
+	dy := math.Max(math.Max(minY-py, py-maxY), 0)
+
+	// Сглаживание края шириной в один пиксель
+	coverage := r.radius - math.Hypot(dx, dy) + 0.5
+	switch {
+	case coverage <= 0:
+		return color.Alpha{}
+	case coverage >= 1:
+		return color.Alpha{A: 255}
+	}
+	return color.Alpha{A: uint8(coverage * 255)}
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage хранит файлы в каталоге локальной файловой системы
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStorage создает локальное хранилище; baseURL - префикс, по которому каталог раздается по HTTP
+func NewLocalStorage(dir, baseURL string) *LocalStorage {
+	return &LocalStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Put записывает файл на диск
+func (s *LocalStorage) Put(ctx context.Context, key string, contentType string, data []byte) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	fullPath := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return "", err
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы не отдавать недописанные файлы
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), fullPath); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// Get читает файл с диска
+func (s *LocalStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Delete удаляет файл с диска; отсутствие файла ошибкой не считается
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// KeyForURL возвращает ключ файла по URL, под которым он раздается
+func (s *LocalStorage) KeyForURL(url string) (string, bool) {
+	prefix := s.baseURL + "/"
+	if !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	key, err := cleanKey(strings.TrimPrefix(url, prefix))
+	return key, err == nil
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrInvalidKey возвращается, если ключ объекта пытается выйти за пределы хранилища
+	ErrInvalidKey = errors.New("invalid storage key")
+	// ErrNotFound возвращается при чтении отсутствующего объекта
+	ErrNotFound = errors.New("storage object not found")
+)
+
+// Storage представляет хранилище загружаемых файлов (аватары, изображения проектов)
+type Storage interface {
+	// Put сохраняет объект под указанным ключом и возвращает его публичный URL
+	Put(ctx context.Context, key string, contentType string, data []byte) (string, error)
+	// Get читает объект по ключу; для отсутствующего объекта возвращает ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete удаляет объект по ключу
+	Delete(ctx context.Context, key string) error
+	// KeyForURL возвращает ключ объекта по его публичному URL, если URL указывает на это хранилище
+	KeyForURL(url string) (string, bool)
+}
+
+// cleanKey нормализует ключ объекта и отклоняет попытки обхода каталогов
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + strings.ReplaceAll(key, "\\", "/"))
+	cleaned = strings.TrimPrefix(cleaned, "/")
+	if cleaned == "" || cleaned == "." || cleaned != strings.TrimPrefix(key, "/") {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}